@@ -0,0 +1,277 @@
+package avrox
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/hamba/avro/v2"
+)
+
+var ErrAnyValueNotSupported = sentinel("ErrAnyValueNotSupported", "type is not supported as any value")
+
+// The branches of the AnyValue union (see avsc/basic_map_string_any.avsc). The order
+// of the first eight branches is the same as in the former flat union, so older data
+// decodes unchanged.
+const (
+	anyNull int64 = iota
+	anyBoolean
+	anyInt
+	anyLong
+	anyFloat
+	anyDouble
+	anyString
+	anyBytes
+	anyTimestamp
+	anyDecimal
+	anyMap
+	anyArray
+)
+
+// anyDecimalPrecision and anyDecimalScale are the ones of the decimal branch (the same as BasicDecimal uses)
+const (
+	anyDecimalPrecision = 17
+	anyDecimalScale     = 4
+)
+
+// selfCodec is implemented by schemers which can not be encoded through
+// hamba/avro reflection (like the recursive AnyValue). The methods read and
+// write the fields of the (uncompressed) avro record after the magic.
+type selfCodec interface {
+	encodeAvro(w *avro.Writer)
+	decodeAvro(r *avro.Reader)
+}
+
+func marshalSelf(magic [MagicLen]byte, sc selfCodec) ([]byte, error) {
+	w := avro.NewWriter(nil, 512)
+	_, _ = w.Write(magic[:])
+	sc.encodeAvro(w)
+	if w.Error != nil {
+		return nil, w.Error
+	}
+	return w.Buffer(), nil
+}
+
+func unmarshalSelf(data []byte, sc selfCodec) error {
+	r := avro.NewReader(nil, 0).Reset(data)
+	var magic [MagicLen]byte
+	r.Read(magic[:])
+	sc.decodeAvro(r)
+	if r.Error != nil {
+		return r.Error
+	}
+	return setMagicField(sc, magic)
+}
+
+// setMagicField sets the magic field of the schemer (which must be a pointer)
+func setMagicField(dst any, magic [MagicLen]byte) error {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return ErrNoPointerDestination
+	}
+	field := v.Elem().FieldByName(MagicFieldName)
+	if !field.IsValid() {
+		return ErrMissingMagicField
+	}
+	field.Set(reflect.ValueOf(magic))
+	return nil
+}
+
+// writeAnyMap writes a map of AnyValue with the keys in sorted order
+func writeAnyMap(w *avro.Writer, m reflect.Value) {
+	if m.Len() > 0 {
+		keys := make([]string, 0, m.Len())
+		values := make(map[string]reflect.Value, m.Len())
+		iter := m.MapRange()
+		for iter.Next() {
+			key := iter.Key().String()
+			keys = append(keys, key)
+			values[key] = iter.Value()
+		}
+		sort.Strings(keys)
+		w.WriteLong(int64(len(keys)))
+		for _, key := range keys {
+			w.WriteString(key)
+			writeAnyValue(w, values[key])
+		}
+	}
+	w.WriteLong(0)
+}
+
+// writeAnyValue writes the union index and the value for the go type of v
+func writeAnyValue(w *avro.Writer, v reflect.Value) {
+	if w.Error != nil {
+		return
+	}
+	for v.Kind() == reflect.Interface || v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			w.WriteLong(anyNull)
+			return
+		}
+		if v.Type() == ratPtrType {
+			break
+		}
+		v = v.Elem()
+	}
+	switch v.Type() {
+	case timeType:
+		w.WriteLong(anyTimestamp)
+		w.WriteLong(v.Interface().(time.Time).UnixMilli())
+		return
+	case ratPtrType:
+		writeAnyDecimal(w, v.Interface().(*big.Rat))
+		return
+	case ratType:
+		r := v.Interface().(big.Rat)
+		writeAnyDecimal(w, &r)
+		return
+	}
+	//nolint:exhaustive // the others are not supported
+	switch v.Kind() {
+	case reflect.Bool:
+		w.WriteLong(anyBoolean)
+		w.WriteBool(v.Bool())
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16:
+		w.WriteLong(anyInt)
+		w.WriteInt(int32(reflectInt(v)))
+	case reflect.Int:
+		// int keeps its type as long as it fits into the avro int
+		if i := v.Int(); i >= math.MinInt32 && i <= math.MaxInt32 {
+			w.WriteLong(anyInt)
+			w.WriteInt(int32(i))
+		} else {
+			w.WriteLong(anyLong)
+			w.WriteLong(i)
+		}
+	case reflect.Int64, reflect.Uint32, reflect.Uint, reflect.Uint64:
+		if v.CanUint() && v.Uint() > math.MaxInt64 {
+			w.Error = errors.Join(ErrAnyValueNotSupported, errors.New("unsigned value overflows long"))
+			return
+		}
+		w.WriteLong(anyLong)
+		w.WriteLong(reflectInt(v))
+	case reflect.Float32:
+		w.WriteLong(anyFloat)
+		w.WriteFloat(float32(v.Float()))
+	case reflect.Float64:
+		w.WriteLong(anyDouble)
+		w.WriteDouble(v.Float())
+	case reflect.String:
+		w.WriteLong(anyString)
+		w.WriteString(v.String())
+	case reflect.Slice, reflect.Array:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			w.WriteLong(anyBytes)
+			b := make([]byte, v.Len())
+			reflect.Copy(reflect.ValueOf(b), v)
+			w.WriteBytes(b)
+			return
+		}
+		w.WriteLong(anyArray)
+		if v.Len() > 0 {
+			w.WriteLong(int64(v.Len()))
+			for i := 0; i < v.Len(); i++ {
+				writeAnyValue(w, v.Index(i))
+			}
+		}
+		w.WriteLong(0)
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			w.Error = errors.Join(ErrAnyValueNotSupported, errors.New("map keys must be strings"))
+			return
+		}
+		w.WriteLong(anyMap)
+		writeAnyMap(w, v)
+	default:
+		w.Error = errors.Join(ErrAnyValueNotSupported, errors.New(v.Type().String()))
+	}
+}
+
+// writeAnyDecimal writes r to the decimal branch. It fails instead of rounding or
+// overflowing (like MarshalDecimalStrict).
+func writeAnyDecimal(w *avro.Writer, r *big.Rat) {
+	if err := CheckDecimal(r, anyDecimalPrecision, anyDecimalScale); err != nil {
+		w.Error = fmt.Errorf("%w: %s", err, r.RatString())
+		return
+	}
+	w.WriteLong(anyDecimal)
+	w.WriteBytes(decimalBytes(r, anyDecimalScale))
+}
+
+func reflectInt(v reflect.Value) int64 {
+	if v.CanInt() {
+		return v.Int()
+	}
+	return int64(v.Uint())
+}
+
+// readAnyMap reads a map of AnyValue
+func readAnyMap(r *avro.Reader) map[string]any {
+	m := map[string]any{}
+	for {
+		count, _ := r.ReadBlockHeader()
+		if count == 0 || r.Error != nil {
+			return m
+		}
+		for i := int64(0); i < count && r.Error == nil; i++ {
+			key := r.ReadString()
+			m[key] = readAnyValue(r)
+		}
+	}
+}
+
+// readAnyValue reads an AnyValue. Maps are returned as map[string]any,
+// arrays as []any, timestamps as time.Time (UTC) and decimals as *big.Rat.
+func readAnyValue(r *avro.Reader) any {
+	idx := r.ReadLong()
+	if r.Error != nil {
+		return nil
+	}
+	switch idx {
+	case anyNull:
+		return nil
+	case anyBoolean:
+		return r.ReadBool()
+	case anyInt:
+		return int(r.ReadInt())
+	case anyLong:
+		return r.ReadLong()
+	case anyFloat:
+		return r.ReadFloat()
+	case anyDouble:
+		return r.ReadDouble()
+	case anyString:
+		return r.ReadString()
+	case anyBytes:
+		return append([]byte{}, r.ReadBytes()...)
+	case anyTimestamp:
+		return time.UnixMilli(r.ReadLong()).UTC()
+	case anyDecimal:
+		return decimalRat(r.ReadBytes(), anyDecimalScale)
+	case anyMap:
+		return readAnyMap(r)
+	case anyArray:
+		a := []any{}
+		for {
+			count, _ := r.ReadBlockHeader()
+			if count == 0 || r.Error != nil {
+				return a
+			}
+			for i := int64(0); i < count && r.Error == nil; i++ {
+				a = append(a, readAnyValue(r))
+			}
+		}
+	default:
+		r.ReportError("AnyValue", "union index out of range")
+		return nil
+	}
+}
+
+var (
+	ratType    = reflect.TypeOf(big.Rat{})
+	ratPtrType = reflect.TypeOf(&big.Rat{})
+)
@@ -21,6 +21,12 @@ func UnpackSchemVer(schemaVerID SchemaID) (int, int) {
 	return int(schemaVerID >> 8), int(schemaVerID & 0xff)
 }
 
+// NSV returns the "N.S.V" notation (namespace, schema, version) for the given ids (e.g. "1.3.1")
+func NSV(nID NamespaceID, sID SchemaID) string {
+	s, v := UnpackSchemVer(sID)
+	return fmt.Sprintf("%d.%d.%d", nID, s, v)
+}
+
 const (
 	CompNone   CompressionID = 0
 	CompSnappy CompressionID = 1
@@ -48,36 +54,68 @@ const (
 )
 
 var (
-	ErrLengthInvalid           = errors.New("data length should be exactly 8 bytes")
-	ErrNamespaceIDOutOfRange   = errors.New("namespace must be between 0 and 31")
-	ErrCompressionIDOutOfRange = errors.New("compression must be between 0 and 7")
-	ErrCompressionUnsupported  = errors.New("compression type is unsupported")
-	ErrSchemaIDOutOfRange      = errors.New("schema must be between 0 and 8191")
-	ErrMarkerInvalid           = fmt.Errorf("data should start with magic marker (0x%02x)", Marker)
-	ErrParityCheckFailed       = errors.New("parity check failed")
-	ErrMarshallingFailed       = errors.New("marshalling failed")
-	ErrMissingMagicField       = errors.New("missing magic field in struct")
-	ErrMarshallAnyWithoutPtr   = errors.New("no ptr src for MarshalAny")
-	ErrSchemaNil               = errors.New("schema is nil")
-	ErrSchemaInvalid           = errors.New("schema is invalid")
-	ErrDecompress              = errors.New("can not decompress")
-	ErrDataFormatNotDetected   = errors.New("message format was not detected")
-	ErrNoData                  = errors.New("no data")
-	ErrNoBasicNamespace        = errors.New("no basic namespace")
-	ErrNoBasicSchema           = errors.New("no basic schema")
-	ErrNoBasicString           = errors.New("no basic string")
-	ErrNoBasicInt              = errors.New("no basic int")
-	ErrNoBasicByteSlice        = errors.New("no basic byte slice")
-	ErrNoBasicMapStringAny     = errors.New("no basic map string any")
-	ErrNoBasicTime             = errors.New("no basic time")
-	ErrNoBasicRawDate          = errors.New("no basic rawdate")
-	ErrNoBasicDecimal          = errors.New("no basic decimal")
-	ErrWrongNamespace          = errors.New("namespace from schemer does not fit the magic entry")
-	ErrWrongSchema             = errors.New("schema from schemer does not fit the magic entry")
-	ErrNotAvroX                = errors.New("data is not avrox")
-	ErrNoPointerDestination    = errors.New("not a pointer destination")
-	ErrSchemerNotFound         = errors.New("schema from schemer is not in the given slice")
-	//ErrBasicTypeNotSupported    = errors.New("basic type not supported")
+	ErrLengthInvalid           = sentinel("ErrLengthInvalid", "data length should be exactly 8 bytes")
+	ErrNamespaceIDOutOfRange   = sentinel("ErrNamespaceIDOutOfRange", "namespace must be between 0 and 31")
+	ErrCompressionIDOutOfRange = sentinel("ErrCompressionIDOutOfRange", "compression must be between 0 and 7")
+	ErrCompressionUnsupported  = sentinel("ErrCompressionUnsupported", "compression type is unsupported")
+	ErrSchemaIDOutOfRange      = sentinel("ErrSchemaIDOutOfRange", "schema must be between 0 and 8191")
+	ErrMarkerInvalid           = sentinel("ErrMarkerInvalid", fmt.Sprintf("data should start with magic marker (0x%02x)", Marker))
+	ErrParityCheckFailed       = sentinel("ErrParityCheckFailed", "parity check failed")
+	ErrMarshallingFailed       = sentinel("ErrMarshallingFailed", "marshalling failed")
+	ErrMissingMagicField       = sentinel("ErrMissingMagicField", "missing magic field in struct")
+	ErrMarshallAnyWithoutPtr   = sentinel("ErrMarshallAnyWithoutPtr", "no ptr src for MarshalAny")
+	ErrSchemaNil               = sentinel("ErrSchemaNil", "schema is nil")
+	ErrSchemaInvalid           = sentinel("ErrSchemaInvalid", "schema is invalid")
+	ErrDecompress              = sentinel("ErrDecompress", "can not decompress")
+	ErrDataFormatNotDetected   = sentinel("ErrDataFormatNotDetected", "message format was not detected")
+	ErrNoData                  = sentinel("ErrNoData", "no data")
+	ErrNoBasicNamespace        = sentinel("ErrNoBasicNamespace", "no basic namespace")
+	ErrNoBasicSchema           = sentinel("ErrNoBasicSchema", "no basic schema")
+	ErrNoBasicString           = sentinel("ErrNoBasicString", "no basic string")
+	ErrNoBasicInt              = sentinel("ErrNoBasicInt", "no basic int")
+	ErrNoBasicByteSlice        = sentinel("ErrNoBasicByteSlice", "no basic byte slice")
+	ErrNoBasicMapStringAny     = sentinel("ErrNoBasicMapStringAny", "no basic map string any")
+	ErrNoBasicTime             = sentinel("ErrNoBasicTime", "no basic time")
+	ErrNoBasicRawDate          = sentinel("ErrNoBasicRawDate", "no basic rawdate")
+	ErrNoBasicDecimal          = sentinel("ErrNoBasicDecimal", "no basic decimal")
+	ErrNoBasicBool             = sentinel("ErrNoBasicBool", "no basic bool")
+	ErrNoBasicInt64            = sentinel("ErrNoBasicInt64", "no basic int64")
+	ErrNoBasicUint64           = sentinel("ErrNoBasicUint64", "no basic uint64")
+	ErrNoBasicFloat32          = sentinel("ErrNoBasicFloat32", "no basic float32")
+	ErrNoBasicFloat64          = sentinel("ErrNoBasicFloat64", "no basic float64")
+	ErrNoBasicDuration         = sentinel("ErrNoBasicDuration", "no basic duration")
+	ErrNoBasicUUID             = sentinel("ErrNoBasicUUID", "no basic uuid")
+	ErrNoBasicStringSlice      = sentinel("ErrNoBasicStringSlice", "no basic string slice")
+	ErrNoBasicIntSlice         = sentinel("ErrNoBasicIntSlice", "no basic int slice")
+	ErrNoBasicInt64Slice       = sentinel("ErrNoBasicInt64Slice", "no basic int64 slice")
+	ErrNoBasicFloat64Slice     = sentinel("ErrNoBasicFloat64Slice", "no basic float64 slice")
+	ErrNoBasicMapStringString  = sentinel("ErrNoBasicMapStringString", "no basic map string string")
+	ErrNoBasicMapStringInt     = sentinel("ErrNoBasicMapStringInt", "no basic map string int")
+	ErrNoBasicList             = sentinel("ErrNoBasicList", "no basic list")
+	ErrNoBasicError            = sentinel("ErrNoBasicError", "no basic error")
+	ErrNoBasicDecimalScaled    = sentinel("ErrNoBasicDecimalScaled", "no basic decimal scaled")
+	ErrNoBasicMoney            = sentinel("ErrNoBasicMoney", "no basic money")
+	ErrNoBasicTimeMicros       = sentinel("ErrNoBasicTimeMicros", "no basic time micros")
+	ErrNoBasicTimeNanos        = sentinel("ErrNoBasicTimeNanos", "no basic time nanos")
+	ErrNoBasicZonedTime        = sentinel("ErrNoBasicZonedTime", "no basic zoned time")
+	ErrNoBasicLocalTimestamp   = sentinel("ErrNoBasicLocalTimestamp", "no basic local timestamp")
+	ErrNoBasicIPAddr           = sentinel("ErrNoBasicIPAddr", "no basic ip addr")
+	ErrNoBasicIPPrefix         = sentinel("ErrNoBasicIPPrefix", "no basic ip prefix")
+	ErrNoBasicURL              = sentinel("ErrNoBasicURL", "no basic url")
+	ErrNoBasicMAC              = sentinel("ErrNoBasicMAC", "no basic mac")
+	ErrNoBasicEnvelope         = sentinel("ErrNoBasicEnvelope", "no basic envelope")
+	ErrNoBasicDateRange        = sentinel("ErrNoBasicDateRange", "no basic date range")
+	ErrNoBasicDateRangeSet     = sentinel("ErrNoBasicDateRangeSet", "no basic date range set")
+	ErrNoBasicRecurrence       = sentinel("ErrNoBasicRecurrence", "no basic recurrence")
+	ErrNoBasicNullRawDate      = sentinel("ErrNoBasicNullRawDate", "no basic null raw date")
+	ErrNoBasicRawTime          = sentinel("ErrNoBasicRawTime", "no basic raw time")
+	ErrNoBasicRawDateTime      = sentinel("ErrNoBasicRawDateTime", "no basic raw date time")
+	ErrWrongNamespace          = sentinel("ErrWrongNamespace", "namespace from schemer does not fit the magic entry")
+	ErrWrongSchema             = sentinel("ErrWrongSchema", "schema from schemer does not fit the magic entry")
+	ErrNotAvroX                = sentinel("ErrNotAvroX", "data is not avrox")
+	ErrNoPointerDestination    = sentinel("ErrNoPointerDestination", "not a pointer destination")
+	ErrSchemerNotFound         = sentinel("ErrSchemerNotFound", "schema from schemer is not in the given slice")
+	ErrBasicTypeNotSupported   = sentinel("ErrBasicTypeNotSupported", "basic type not supported")
 )
 
 func MarshalAny(src any, schema avro.Schema, nID NamespaceID, sID SchemaID, cID CompressionID) ([]byte, error) {
@@ -102,7 +140,13 @@ func MarshalAny(src any, schema avro.Schema, nID NamespaceID, sID SchemaID, cID
 		return nil, wfl.ErrorWithSkip(errMagic, 2)
 	}
 	magicField.Set(reflect.ValueOf(magic))
-	data, errMarshal := avro.Marshal(schema, src)
+	var data []byte
+	var errMarshal error
+	if sc, ok := src.(selfCodec); ok {
+		data, errMarshal = marshalSelf(magic, sc)
+	} else {
+		data, errMarshal = avro.Marshal(schema, src)
+	}
 	if errMarshal != nil {
 		return nil, errors.Join(ErrMarshallingFailed, wfl.ErrorWithSkip(errMarshal, 2))
 	}
@@ -140,5 +184,8 @@ func UnmarshalAny[T any](data []byte, schema avro.Schema, dst *T) (NamespaceID,
 		return 0, 0, errHelper
 	}
 
+	if sc, ok := any(dst).(selfCodec); ok {
+		return nID, sID, unmarshalSelf(data, sc)
+	}
 	return nID, sID, avro.Unmarshal(schema, data, dst)
 }
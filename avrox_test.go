@@ -2,11 +2,15 @@ package avrox_test
 
 import (
 	"errors"
+	"fmt"
 	"github.com/hamba/avro/v2"
 	"github.com/metatexx/avrox/rawdate"
 	"github.com/metatexx/avrox/testdata"
 	"math"
 	"math/big"
+	"net"
+	"net/netip"
+	"net/url"
 	"testing"
 	"time"
 
@@ -352,3 +356,1056 @@ func TestMarshalAny(t *testing.T) {
 	assert.NoError(t, err5)
 	assert.Equal(t, mt, testStruct)
 }
+
+func TestContentID(t *testing.T) {
+	plain, err := avrox.MarshalBasic("foobarfoobar", avrox.CompNone)
+	assert.NoError(t, err)
+	snappy, err := avrox.MarshalBasic("foobarfoobar", avrox.CompSnappy)
+	assert.NoError(t, err)
+	other, err := avrox.MarshalBasic("foobar", avrox.CompNone)
+	assert.NoError(t, err)
+
+	idPlain, err := avrox.ContentID(plain)
+	assert.NoError(t, err)
+	idSnappy, err := avrox.ContentID(snappy)
+	assert.NoError(t, err)
+	idOther, err := avrox.ContentID(other)
+	assert.NoError(t, err)
+	assert.Equal(t, idPlain, idSnappy)
+	assert.NotEqual(t, idPlain, idOther)
+	assert.Len(t, idPlain, 64)
+
+	// same avro data with another schema
+	data, err := avrox.Marshal(&avrox.BasicByteSlice{Value: []byte("foobarfoobar")}, avrox.CompNone, nil)
+	assert.NoError(t, err)
+	idBytes, err := avrox.ContentID(data)
+	assert.NoError(t, err)
+	assert.NotEqual(t, idPlain, idBytes)
+
+	_, err = avrox.ContentID([]byte("foo"))
+	assert.ErrorIs(t, err, avrox.ErrDataFormatNotDetected)
+}
+
+func TestMarshalCanonical(t *testing.T) {
+	value := map[string]any{}
+	for i := 0; i < 50; i++ {
+		value[string(rune('a'+i%26))+string(rune('A'+i/26))] = i
+	}
+	first, err := avrox.MarshalCanonical(&avrox.BasicMapStringAny{Value: value}, avrox.CompNone, nil)
+	assert.NoError(t, err)
+	for i := 0; i < 10; i++ {
+		data, errCanonical := avrox.MarshalCanonical(&avrox.BasicMapStringAny{Value: value}, avrox.CompNone, nil)
+		assert.NoError(t, errCanonical)
+		assert.Equal(t, first, data)
+
+		// re-encoding the default encoding results in the same data
+		data, err = avrox.MarshalBasic(value, avrox.CompNone)
+		assert.NoError(t, err)
+		data, err = avrox.Canonical(data)
+		assert.NoError(t, err)
+		assert.Equal(t, first, data)
+	}
+
+	decoded, err := avrox.UnmarshalBasic(first)
+	assert.NoError(t, err)
+	assert.Equal(t, value, decoded)
+
+	snappy, err := avrox.MarshalBasic(value, avrox.CompSnappy)
+	assert.NoError(t, err)
+	snappy, err = avrox.Canonical(snappy)
+	assert.NoError(t, err)
+	_, _, c, err := avrox.DecodeMagic(snappy[:avrox.MagicLen])
+	assert.NoError(t, err)
+	assert.Equal(t, avrox.CompSnappy, c)
+	direct, err := avrox.MarshalCanonical(&avrox.BasicMapStringAny{Value: value}, avrox.CompSnappy, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, snappy, direct)
+	idSnappy, err := avrox.ContentID(snappy)
+	assert.NoError(t, err)
+	idFirst, err := avrox.ContentID(first)
+	assert.NoError(t, err)
+	assert.Equal(t, idFirst, idSnappy)
+
+	private := avrox.MustEncodePrivateMagic(avrox.CompNone)
+	_, err = avrox.Canonical(private[:])
+	assert.ErrorIs(t, err, avrox.ErrSchemerNotFound)
+}
+
+func TestNormalizeTimes(t *testing.T) {
+	loc := time.FixedZone("UTC+2", 2*60*60)
+	local := time.Date(2024, 3, 1, 0, 30, 0, 123456789, loc)
+	utc := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	mt := &testdata.TestStruct{FieldTime: local, FieldDate: local}
+	schema := avro.MustParse(testdata.TestStructAVSC)
+	avrox.NormalizeTimes(mt, schema)
+	assert.Equal(t, utc, mt.FieldDate)
+	assert.Equal(t, avrox.AvroTime(local), mt.FieldTime)
+
+	data, err := avrox.MarshalAny(mt, schema, avrox.NamespacePrivate, avrox.SchemaUndefined, avrox.CompNone)
+	assert.NoError(t, err)
+	decoded := &testdata.TestStruct{}
+	_, _, err = avrox.UnmarshalAny(data, schema, decoded)
+	assert.NoError(t, err)
+	assert.Equal(t, utc, decoded.FieldDate)
+	assert.Equal(t, avrox.AvroTime(local), decoded.FieldTime)
+}
+
+type testEvent struct {
+	Magic [avrox.MagicLen]byte
+	At    time.Time
+	Attrs map[string]any
+}
+
+func (testEvent) Schema() string {
+	return `{"type":"record","name":"Event","fields":[
+		{"name":"Magic","type":{"name":"Magic_8","size":8,"type":"fixed"}},
+		{"name":"At","type":{"type":"long","logicalType":"timestamp-millis"}},
+		{"name":"Attrs","type":{"type":"map","values":["null","string",{"type":"long","logicalType":"timestamp-millis"}]}}]}`
+}
+
+func (testEvent) NamespaceID() avrox.NamespaceID {
+	return avrox.NamespacePrivate
+}
+
+func (testEvent) SchemaID() avrox.SchemaID {
+	return avrox.PackSchemVer(8, 1)
+}
+
+func TestMarshalCanonical_Copy(t *testing.T) {
+	local := time.Date(2024, 3, 1, 0, 30, 0, 123456789, time.FixedZone("UTC+2", 2*60*60))
+	event := &testEvent{At: local, Attrs: map[string]any{"seen": local, "by": "me"}}
+	data, err := avrox.MarshalCanonical(event, avrox.CompNone, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, &testEvent{At: local, Attrs: map[string]any{"seen": local, "by": "me"}}, event)
+
+	normalized := &testEvent{At: avrox.AvroTime(local), Attrs: map[string]any{"seen": avrox.AvroTime(local), "by": "me"}}
+	other, err := avrox.MarshalCanonical(normalized, avrox.CompNone, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, data, other)
+
+	decoded := &testEvent{}
+	_, _, err = avrox.UnmarshalAny(data, avro.MustParse(event.Schema()), decoded)
+	assert.NoError(t, err)
+	assert.Equal(t, avrox.AvroTime(local), decoded.At)
+	assert.Equal(t, avrox.AvroTime(local), decoded.Attrs["seen"])
+
+	avrox.NormalizeTimes(event, avro.MustParse(event.Schema()))
+	assert.Equal(t, avrox.AvroTime(local), event.Attrs["seen"])
+}
+
+func TestMarshalBasicValue(t *testing.T) {
+	data, err := avrox.MarshalBasicValue("foo", avrox.CompSnappy)
+	assert.NoError(t, err)
+	str, err := avrox.UnmarshalBasicAs[string](data)
+	assert.NoError(t, err)
+	assert.Equal(t, "foo", str)
+
+	_, err = avrox.UnmarshalBasicAs[int](data)
+	assert.ErrorIs(t, err, avrox.ErrNoBasicInt)
+	_, err = avrox.UnmarshalInt(data)
+	assert.ErrorIs(t, err, avrox.ErrNoBasicInt)
+	_, err = avrox.UnmarshalBasicAs[int32](data)
+	assert.ErrorIs(t, err, avrox.ErrBasicTypeNotSupported)
+
+	date := rawdate.MustNew(2024, 2, 29)
+	data, err = avrox.MarshalBasicValue(&date, avrox.CompNone)
+	assert.NoError(t, err)
+	date2, err := avrox.UnmarshalBasicAs[rawdate.RawDate](data)
+	assert.NoError(t, err)
+	assert.Equal(t, date, date2)
+
+	value, _ := (&big.Rat{}).SetString("-12345/100")
+	data, err = avrox.MarshalBasicValue(value, avrox.CompNone)
+	assert.NoError(t, err)
+	decimal := &avrox.BasicDecimal{}
+	assert.NoError(t, avrox.Unmarshal(data, decimal, nil))
+	assert.Equal(t, avrox.BasicDecimalSchemaID, decimal.SchemaID())
+	assert.Equal(t, value, decimal.Value)
+
+	_, err = avrox.MarshalBasic(struct{}{}, avrox.CompNone)
+	assert.ErrorIs(t, err, avrox.ErrBasicTypeNotSupported)
+	var nilString *string
+	_, err = avrox.MarshalBasic(nilString, avrox.CompNone)
+	assert.ErrorIs(t, err, avrox.ErrBasicTypeNotSupported)
+}
+
+func TestMarshalBasicExtended(t *testing.T) {
+	id, err := avrox.NewUUID()
+	assert.NoError(t, err)
+	testCases := []struct {
+		value    any
+		schemaID avrox.SchemaID
+	}{
+		{true, avrox.BasicBoolSchemaID},
+		{int64(math.MinInt64), avrox.BasicInt64SchemaID},
+		{uint64(math.MaxUint64), avrox.BasicUint64SchemaID},
+		{float32(math.Pi), avrox.BasicFloat32SchemaID},
+		{math.MaxFloat64, avrox.BasicFloat64SchemaID},
+		{-90*time.Minute - time.Nanosecond, avrox.BasicDurationSchemaID},
+		{id, avrox.BasicUUIDSchemaID},
+	}
+	for _, tc := range testCases {
+		data, errMarshal := avrox.MarshalBasic(tc.value, avrox.CompSnappy)
+		assert.NoError(t, errMarshal)
+		n, s, c, errMagic := avrox.DecodeMagic(data[:avrox.MagicLen])
+		assert.NoError(t, errMagic)
+		assert.Equal(t, avrox.NamespaceBasic, n)
+		assert.Equal(t, tc.schemaID, s)
+		assert.Equal(t, avrox.CompSnappy, c)
+		result, errUnmarshal := avrox.UnmarshalBasic(data)
+		assert.NoError(t, errUnmarshal)
+		assert.Equal(t, tc.value, result)
+	}
+
+	data, err := avrox.MarshalBasicValue(uint64(1)<<63+1, avrox.CompNone)
+	assert.NoError(t, err)
+	u, err := avrox.UnmarshalUint64(data)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1)<<63+1, u)
+	_, err = avrox.UnmarshalInt64(data)
+	assert.ErrorIs(t, err, avrox.ErrNoBasicInt64)
+
+	data, err = avrox.MarshalBasic(&id, avrox.CompNone)
+	assert.NoError(t, err)
+	id2, err := avrox.UnmarshalUUID(data)
+	assert.NoError(t, err)
+	assert.Equal(t, id, id2)
+	// the uuid is stored as string
+	assert.Contains(t, string(data), id.String())
+
+	data, err = avrox.MarshalBasic(false, avrox.CompNone)
+	assert.NoError(t, err)
+	b, err := avrox.UnmarshalBool(data)
+	assert.NoError(t, err)
+	assert.False(t, b)
+	_, err = avrox.UnmarshalFloat64(data)
+	assert.ErrorIs(t, err, avrox.ErrNoBasicFloat64)
+}
+
+func TestUUID(t *testing.T) {
+	u, err := avrox.ParseUUID("6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+	assert.NoError(t, err)
+	assert.Equal(t, "6ba7b810-9dad-11d1-80b4-00c04fd430c8", u.String())
+	assert.False(t, u.IsZero())
+
+	_, err = avrox.ParseUUID("6ba7b810-9dad-11d1-80b4-00c04fd430c")
+	assert.ErrorIs(t, err, avrox.ErrUUIDInvalid)
+	_, err = avrox.ParseUUID("6ba7b810-9dad-11d1-80b4-00c04fd430cx")
+	assert.ErrorIs(t, err, avrox.ErrUUIDInvalid)
+
+	n, err := avrox.NewUUID()
+	assert.NoError(t, err)
+	assert.Equal(t, byte(0x40), n[6]&0xf0)
+	assert.Equal(t, n, avrox.MustParseUUID(n.String()))
+}
+
+func TestMarshalBasicContainers(t *testing.T) {
+	testCases := []struct {
+		value    any
+		schemaID avrox.SchemaID
+	}{
+		{[]string{"foo", "bar"}, avrox.BasicStringSliceSchemaID},
+		{[]int{1, -2, 3}, avrox.BasicIntSliceSchemaID},
+		{[]int64{math.MaxInt64, math.MinInt64}, avrox.BasicInt64SliceSchemaID},
+		{[]float64{math.Pi, -math.E}, avrox.BasicFloat64SliceSchemaID},
+		{map[string]string{"env": "prod", "team": "core"}, avrox.BasicMapStringStringSchemaID},
+		{map[string]int{"a": 1, "b": -2}, avrox.BasicMapStringIntSchemaID},
+	}
+	for _, tc := range testCases {
+		data, errMarshal := avrox.MarshalBasic(tc.value, avrox.CompNone)
+		assert.NoError(t, errMarshal)
+		n, s, _, errMagic := avrox.DecodeMagic(data[:avrox.MagicLen])
+		assert.NoError(t, errMagic)
+		assert.Equal(t, avrox.NamespaceBasic, n)
+		assert.Equal(t, tc.schemaID, s)
+		result, errUnmarshal := avrox.UnmarshalBasic(data)
+		assert.NoError(t, errUnmarshal)
+		assert.Equal(t, tc.value, result)
+	}
+
+	data, err := avrox.MarshalBasicValue([]string{"a", "b", "c"}, avrox.CompSnappy)
+	assert.NoError(t, err)
+	tags, err := avrox.UnmarshalStringSlice(data)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "b", "c"}, tags)
+	_, err = avrox.UnmarshalIntSlice(data)
+	assert.ErrorIs(t, err, avrox.ErrNoBasicIntSlice)
+}
+
+func TestMarshalBasicList(t *testing.T) {
+	list, err := avrox.NewList(avrox.CompSnappy,
+		&avrox.BasicString{Value: "foo"}, &avrox.BasicInt{Value: 42}, &avrox.BasicString{Value: "bar"})
+	assert.NoError(t, err)
+	data, err := avrox.MarshalBasic(list, avrox.CompNone)
+	assert.NoError(t, err)
+
+	decoded, err := avrox.UnmarshalList(data)
+	assert.NoError(t, err)
+	assert.Equal(t, list, decoded)
+
+	values, err := decoded.Basics()
+	assert.NoError(t, err)
+	assert.Equal(t, []any{"foo", 42, "bar"}, values)
+
+	schemers, err := decoded.Schemers(&avrox.BasicString{}, (*avrox.BasicInt)(nil))
+	assert.NoError(t, err)
+	assert.Len(t, schemers, 3)
+	assert.Equal(t, "foo", schemers[0].(*avrox.BasicString).Value)
+	assert.Equal(t, 42, schemers[1].(*avrox.BasicInt).Value)
+	assert.Equal(t, "bar", schemers[2].(*avrox.BasicString).Value)
+
+	_, err = decoded.Schemers(&avrox.BasicString{})
+	assert.ErrorIs(t, err, avrox.ErrSchemerNotFound)
+	_, err = decoded.Schemers(nil)
+	assert.ErrorIs(t, err, avrox.ErrNoPointerDestination)
+}
+
+func TestMarshalMapStringAnyNested(t *testing.T) {
+	ts := time.Date(2024, 2, 29, 12, 30, 15, 123000000, time.UTC)
+	value := map[string]any{
+		"name":   "doc",
+		"count":  3,
+		"big":    int64(math.MaxInt64),
+		"ratio":  0.5,
+		"nil":    nil,
+		"raw":    []byte{1, 2, 3},
+		"when":   ts,
+		"amount": big.NewRat(12345, 100),
+		"tags":   []any{"a", 1, []any{true, nil}},
+		"nested": map[string]any{"deep": map[string]any{"x": float32(1.5)}, "empty": map[string]any{}},
+	}
+	data, err := avrox.MarshalBasic(value, avrox.CompSnappy)
+	assert.NoError(t, err)
+	decoded, err := avrox.UnmarshalBasic(data)
+	assert.NoError(t, err)
+	assert.Equal(t, value, decoded)
+
+	// typed slices and maps are written as generic values
+	data, err = avrox.MarshalBasic(map[string]any{"ids": []int{1, 2}, "labels": map[string]string{"a": "b"}}, avrox.CompNone)
+	assert.NoError(t, err)
+	decoded, err = avrox.UnmarshalBasic(data)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{"ids": []any{1, 2}, "labels": map[string]any{"a": "b"}}, decoded)
+
+	_, err = avrox.MarshalBasic(map[string]any{"bad": map[int]string{1: "a"}}, avrox.CompNone)
+	assert.ErrorIs(t, err, avrox.ErrAnyValueNotSupported)
+	_, err = avrox.MarshalBasic(map[string]any{"bad": struct{}{}}, avrox.CompNone)
+	assert.ErrorIs(t, err, avrox.ErrAnyValueNotSupported)
+
+	// decimals are not rounded or overflowed
+	_, err = avrox.MarshalBasic(map[string]any{"third": big.NewRat(1, 3)}, avrox.CompNone)
+	assert.ErrorIs(t, err, avrox.ErrDecimalInexact)
+	_, err = avrox.MarshalBasic(map[string]any{"tiny": big.NewRat(1, 100000)}, avrox.CompNone)
+	assert.ErrorIs(t, err, avrox.ErrDecimalInexact)
+	_, err = avrox.MarshalBasic(map[string]any{"huge": new(big.Rat).SetInt64(math.MaxInt64)}, avrox.CompNone)
+	assert.ErrorIs(t, err, avrox.ErrDecimalOverflow)
+
+	// data of the former flat union still gets unmarshalled and can be migrated
+	old := &avrox.BasicMapStringAnyV1{Value: map[string]any{"foo": 1, "bar": "baz", "error": false}}
+	data, err = avrox.Marshal(old, avrox.CompSnappy, nil)
+	assert.NoError(t, err)
+	decoded, err = avrox.UnmarshalBasic(data)
+	assert.NoError(t, err)
+	assert.Equal(t, old.Value, decoded)
+
+	// the canonical re-encoding walks the former schema
+	canonical, err := avrox.Canonical(data)
+	assert.NoError(t, err)
+	decoded, err = avrox.UnmarshalBasic(canonical)
+	assert.NoError(t, err)
+	assert.Equal(t, old.Value, decoded)
+
+	migrated, err := avrox.MigrateBasicMapStringAny(data)
+	assert.NoError(t, err)
+	_, s, c, err := avrox.DecodeMagic(migrated[:avrox.MagicLen])
+	assert.NoError(t, err)
+	assert.Equal(t, avrox.BasicMapStringAnySchemaID, s)
+	assert.Equal(t, avrox.CompSnappy, c)
+	decoded, err = avrox.UnmarshalBasic(migrated)
+	assert.NoError(t, err)
+	assert.Equal(t, old.Value, decoded)
+
+	// the canonical re-encoding walks the recursive schema
+	canonical, err = avrox.Canonical(migrated)
+	assert.NoError(t, err)
+	decoded, err = avrox.UnmarshalBasic(canonical)
+	assert.NoError(t, err)
+	assert.Equal(t, old.Value, decoded)
+}
+
+var errOutOfStock = errors.New("out of stock")
+
+func init() {
+	avrox.RegisterErrorCode("shop.OutOfStock", errOutOfStock)
+}
+
+type sliceError []string
+
+func (e sliceError) Error() string { return "slice error" }
+
+func TestRegisterErrorCode(t *testing.T) {
+	for code, err := range map[string]error{
+		"avrox.ErrNoData":              avrox.ErrNoData,
+		"avrox.ErrMarkerInvalid":       avrox.ErrMarkerInvalid,
+		"avrox.ErrNoBasicString":       avrox.ErrNoBasicString,
+		"avrox.ErrNoBasicRawDate":      avrox.ErrNoBasicRawDate,
+		"avrox.ErrNoBasicRawDateTime":  avrox.ErrNoBasicRawDateTime,
+		"avrox.ErrNoBasicMapStringAny": avrox.ErrNoBasicMapStringAny,
+		"avrox.ErrDecimalScale":        avrox.ErrDecimalScale,
+	} {
+		got, found := avrox.ErrorCode(err)
+		assert.True(t, found, code)
+		assert.Equal(t, code, got)
+	}
+
+	// registering the same pair again is fine
+	avrox.RegisterErrorCode("shop.OutOfStock", errOutOfStock)
+	assert.Panics(t, func() { avrox.RegisterErrorCode("shop.OutOfStock", errors.New("other")) })
+	assert.Panics(t, func() { avrox.RegisterErrorCode("shop.Other", errOutOfStock) })
+	assert.Panics(t, func() { avrox.RegisterErrorCode("shop.Slice", sliceError{"a"}) })
+	_, found := avrox.ErrorCode(sliceError{"a"})
+	assert.False(t, found)
+}
+
+func TestMarshalError(t *testing.T) {
+	original := errors.Join(
+		fmt.Errorf("decoding order: %w", avrox.ErrWrongSchema),
+		&avrox.RemoteError{Code: "shop.OutOfStock", Message: "out of stock", Details: map[string]string{"sku": "A-1"}, NSV: "0.7.1"},
+		errors.New("something else"),
+	)
+	data, err := avrox.MarshalError(original, avrox.CompSnappy)
+	assert.NoError(t, err)
+
+	decoded, err := avrox.UnmarshalError(data)
+	assert.NoError(t, err)
+	assert.Equal(t, original.Error(), decoded.Error())
+	assert.ErrorIs(t, decoded, avrox.ErrWrongSchema)
+	assert.NotErrorIs(t, decoded, avrox.ErrNoData)
+
+	var remote *avrox.RemoteError
+	assert.ErrorAs(t, decoded.Causes[1], &remote)
+	assert.Equal(t, "shop.OutOfStock", remote.Code)
+	assert.Equal(t, map[string]string{"sku": "A-1"}, remote.Details)
+	assert.Equal(t, "0.7.1", remote.NSV)
+	assert.Len(t, decoded.Causes, 3)
+	assert.Len(t, decoded.Causes[0].(*avrox.RemoteError).Causes, 1)
+
+	assert.ErrorIs(t, decoded, errOutOfStock)
+
+	value, err := avrox.UnmarshalBasic(data)
+	assert.NoError(t, err)
+	assert.ErrorIs(t, value.(error), avrox.ErrWrongSchema)
+
+	data, err = avrox.MarshalError(nil, avrox.CompNone)
+	assert.NoError(t, err)
+	decoded, err = avrox.UnmarshalError(data)
+	assert.NoError(t, err)
+	assert.Nil(t, decoded)
+
+	data, err = avrox.MarshalBasic("no error", avrox.CompNone)
+	assert.NoError(t, err)
+	_, err = avrox.UnmarshalError(data)
+	assert.ErrorIs(t, err, avrox.ErrNoBasicError)
+}
+
+func TestDecimal(t *testing.T) {
+	testCases := []struct {
+		in     string
+		scale  int
+		round  string
+		strict error
+	}{
+		{"1/10", 2, "0.10", nil},
+		{"1/3", 4, "0.3333", avrox.ErrDecimalInexact},
+		{"5/2", 0, "2", avrox.ErrDecimalInexact},
+		{"7/2", 0, "4", avrox.ErrDecimalInexact},
+		{"-7/2", 0, "-4", avrox.ErrDecimalInexact},
+		{"123456789012345678901234567890/1", 0, "123456789012345678901234567890", nil},
+		{"-1/8", 3, "-0.125", nil},
+	}
+	for _, tc := range testCases {
+		r, _ := new(big.Rat).SetString(tc.in)
+		d, err := avrox.NewDecimal(r, tc.scale)
+		assert.NoError(t, err)
+		assert.Equal(t, tc.round, d.String(), tc.in)
+		_, err = avrox.NewDecimalExact(r, tc.scale)
+		assert.ErrorIs(t, err, tc.strict)
+		if tc.strict != nil {
+			assert.Error(t, err)
+		}
+	}
+
+	d, err := avrox.ParseDecimal("-0.00012345")
+	assert.NoError(t, err)
+	assert.Equal(t, 8, d.Scale)
+	data, err := avrox.MarshalBasic(d, avrox.CompNone)
+	assert.NoError(t, err)
+	decoded, err := avrox.UnmarshalDecimal(data)
+	assert.NoError(t, err)
+	assert.Equal(t, "-0.00012345", decoded.String())
+	assert.Equal(t, 0, d.Cmp(decoded))
+
+	for _, bad := range []string{"", "-", "1.2.3", "1e5", "--1", "."} {
+		_, err = avrox.ParseDecimal(bad)
+		assert.ErrorIs(t, err, avrox.ErrDecimalInvalid, bad)
+	}
+
+	_, err = avrox.DecimalFromRat(big.NewRat(1, 3))
+	assert.ErrorIs(t, err, avrox.ErrDecimalInexact)
+	d, err = avrox.DecimalFromRat(big.NewRat(5, 4))
+	assert.NoError(t, err)
+	assert.Equal(t, "1.25", d.String())
+
+	_, err = avrox.MarshalDecimalStrict(big.NewRat(1, 100000), avrox.CompNone)
+	assert.ErrorIs(t, err, avrox.ErrDecimalInexact)
+	_, err = avrox.MarshalDecimalStrict(new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(14), nil)), avrox.CompNone)
+	assert.ErrorIs(t, err, avrox.ErrDecimalOverflow)
+	_, err = avrox.MarshalDecimalStrict(big.NewRat(1, 8), avrox.CompNone)
+	assert.NoError(t, err)
+
+	// corrupt or hostile scales are rejected instead of computing huge powers of ten
+	for _, scale := range []int{-1, avrox.MaxDecimalScale + 1, 1 << 30} {
+		data, err = avrox.Marshal(&avrox.BasicDecimalScaled{Unscaled: []byte{1}, Scale: scale}, avrox.CompNone, nil)
+		assert.NoError(t, err)
+		_, err = avrox.UnmarshalDecimal(data)
+		assert.ErrorIs(t, err, avrox.ErrDecimalScale, scale)
+	}
+	_, err = avrox.MarshalBasic(avrox.Decimal{Unscaled: big.NewInt(1), Scale: -1}, avrox.CompNone)
+	assert.ErrorIs(t, err, avrox.ErrDecimalScale)
+}
+
+func TestMoney(t *testing.T) {
+	m, err := avrox.NewMoney(big.NewRat(1250, 100), "EUR")
+	assert.NoError(t, err)
+	assert.Equal(t, "12.50 EUR", m.String())
+	_, err = avrox.NewMoney(big.NewRat(1, 2), "JPY")
+	assert.ErrorIs(t, err, avrox.ErrDecimalInexact)
+	_, err = avrox.NewMoney(big.NewRat(1, 2), "eur")
+	assert.ErrorIs(t, err, avrox.ErrCurrencyInvalid)
+
+	data, err := avrox.MarshalBasic(m, avrox.CompSnappy)
+	assert.NoError(t, err)
+	decoded, err := avrox.UnmarshalMoney(data)
+	assert.NoError(t, err)
+	assert.Equal(t, m.String(), decoded.String())
+	assert.NoError(t, decoded.Validate())
+
+	price, err := avrox.ParseMoney("0.1234", "KWD")
+	assert.NoError(t, err)
+	assert.Equal(t, 4, price.Amount.Scale)
+	assert.Equal(t, 3, avrox.CurrencyMinorUnits("KWD"))
+
+	_, err = avrox.MarshalBasic(avrox.Money{Amount: price.Amount, Currency: "XXXX"}, avrox.CompNone)
+	assert.ErrorIs(t, err, avrox.ErrCurrencyInvalid)
+
+	// decoded values get validated too
+	for _, bad := range []avrox.BasicMoney{
+		{Unscaled: []byte{1}, Scale: 2, Currency: "eur"},
+		{Unscaled: []byte{1}, Scale: 1 << 30, Currency: "EUR"},
+	} {
+		data, err = avrox.Marshal(&bad, avrox.CompNone, nil)
+		assert.NoError(t, err)
+		_, err = avrox.UnmarshalMoney(data)
+		assert.Error(t, err)
+	}
+}
+
+func TestMarshalBasicTimestamps(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	assert.NoError(t, err)
+	recorded := time.Date(2024, 3, 31, 2, 30, 15, 123456789, time.FixedZone("", 5*3600)).In(berlin)
+
+	data, err := avrox.MarshalBasic(avrox.TimeMicros{Time: recorded}, avrox.CompNone)
+	assert.NoError(t, err)
+	micros, err := avrox.UnmarshalTimeMicros(data)
+	assert.NoError(t, err)
+	assert.Equal(t, avrox.AvroTimeMicros(recorded), micros.Time)
+
+	data, err = avrox.MarshalBasic(avrox.TimeNanos{Time: recorded}, avrox.CompNone)
+	assert.NoError(t, err)
+	nanos, err := avrox.UnmarshalTimeNanos(data)
+	assert.NoError(t, err)
+	assert.True(t, recorded.Equal(nanos.Time))
+	assert.Equal(t, 123456789, nanos.Nanosecond())
+
+	data, err = avrox.MarshalBasic(avrox.ZonedTime{Time: recorded}, avrox.CompNone)
+	assert.NoError(t, err)
+	zoned, err := avrox.UnmarshalZonedTime(data)
+	assert.NoError(t, err)
+	assert.True(t, recorded.Equal(zoned.Time))
+	assert.Equal(t, "Europe/Berlin", zoned.Location().String())
+	assert.Equal(t, recorded.Format(time.RFC3339Nano), zoned.Format(time.RFC3339Nano))
+
+	// unknown locations fall back to a fixed zone with the stored offset
+	data, err = avrox.MarshalBasic(avrox.ZonedTime{Time: recorded.In(time.FixedZone("XYZ", 3600))}, avrox.CompNone)
+	assert.NoError(t, err)
+	zoned, err = avrox.UnmarshalZonedTime(data)
+	assert.NoError(t, err)
+	assert.Equal(t, "XYZ", zoned.Location().String())
+	_, offset := zoned.Zone()
+	assert.Equal(t, 3600, offset)
+
+	// the name "Local" would be the zone of the reader
+	_, err = avrox.MarshalBasic(avrox.ZonedTime{Time: recorded.In(time.Local)}, avrox.CompNone)
+	assert.ErrorIs(t, err, avrox.ErrZoneInvalid)
+	// a fixed zone named like an IANA location with a different offset
+	summer := time.Date(2024, 7, 1, 12, 0, 0, 0, time.UTC)
+	_, err = avrox.MarshalBasic(avrox.ZonedTime{Time: summer.In(time.FixedZone("Europe/Berlin", 3600))}, avrox.CompNone)
+	assert.ErrorIs(t, err, avrox.ErrZoneInvalid)
+	data, err = avrox.MarshalBasic(avrox.ZonedTime{Time: summer.In(time.UTC)}, avrox.CompNone)
+	assert.NoError(t, err)
+	zoned, err = avrox.UnmarshalZonedTime(data)
+	assert.NoError(t, err)
+	assert.Equal(t, time.UTC, zoned.Location())
+
+	// nanoseconds since 1970 only cover the years 1678 to 2262
+	for _, outside := range []time.Time{{}, time.Date(3000, 1, 1, 0, 0, 0, 0, time.UTC)} {
+		_, err = avrox.MarshalBasic(avrox.TimeNanos{Time: outside}, avrox.CompNone)
+		assert.ErrorIs(t, err, avrox.ErrTimeOutOfRange)
+		_, err = avrox.MarshalBasic(avrox.ZonedTime{Time: outside}, avrox.CompNone)
+		assert.ErrorIs(t, err, avrox.ErrTimeOutOfRange)
+		_, err = avrox.MarshalBasic(avrox.LocalTimestamp{Time: outside}, avrox.CompNone)
+		assert.ErrorIs(t, err, avrox.ErrTimeOutOfRange)
+	}
+
+	data, err = avrox.MarshalBasic(avrox.LocalTimestamp{Time: recorded}, avrox.CompNone)
+	assert.NoError(t, err)
+	local, err := avrox.UnmarshalLocalTimestamp(data)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 3, 30, 22, 30, 15, 123456789, time.UTC), local.Time)
+
+	_, err = avrox.UnmarshalZonedTime(data)
+	assert.ErrorIs(t, err, avrox.ErrNoBasicZonedTime)
+}
+
+func TestMarshalBasicNet(t *testing.T) {
+	mac, err := net.ParseMAC("00:1a:2b:3c:4d:5e")
+	assert.NoError(t, err)
+	link, err := url.Parse("https://user@example.com:8443/path?q=1#frag")
+	assert.NoError(t, err)
+	testCases := []struct {
+		value    any
+		schemaID avrox.SchemaID
+	}{
+		{netip.MustParseAddr("192.168.1.10"), avrox.BasicIPAddrSchemaID},
+		{netip.MustParseAddr("2001:db8::1"), avrox.BasicIPAddrSchemaID},
+		{netip.MustParseAddr("fe80::1%eth0"), avrox.BasicIPAddrSchemaID},
+		{netip.MustParseAddr("::ffff:10.0.0.1"), avrox.BasicIPAddrSchemaID},
+		{netip.Addr{}, avrox.BasicIPAddrSchemaID},
+		{netip.MustParsePrefix("10.0.0.0/8"), avrox.BasicIPPrefixSchemaID},
+		{netip.MustParsePrefix("2001:db8::/32"), avrox.BasicIPPrefixSchemaID},
+		{netip.Prefix{}, avrox.BasicIPPrefixSchemaID},
+		{link, avrox.BasicURLSchemaID},
+		{mac, avrox.BasicMACSchemaID},
+	}
+	for _, tc := range testCases {
+		data, err := avrox.MarshalBasic(tc.value, avrox.CompNone)
+		assert.NoError(t, err)
+		_, s, _, _ := avrox.DecodeMagic(data[:avrox.MagicLen])
+		assert.Equal(t, tc.schemaID, s)
+		result, err := avrox.UnmarshalBasic(data)
+		assert.NoError(t, err)
+		assert.Equal(t, tc.value, result)
+	}
+
+	// IPv4 uses 4 fixed bytes
+	v4, err := avrox.MarshalBasic(netip.MustParseAddr("192.168.1.10"), avrox.CompNone)
+	assert.NoError(t, err)
+	v6, err := avrox.MarshalBasic(netip.MustParseAddr("2001:db8::1"), avrox.CompNone)
+	assert.NoError(t, err)
+	assert.Equal(t, 12, len(v6)-len(v4))
+
+	_, err = avrox.MarshalBasic((*url.URL)(nil), avrox.CompNone)
+	assert.ErrorIs(t, err, avrox.ErrNoData)
+
+	// own records can embed the types
+	type Telemetry struct {
+		Source avrox.IPAddr
+		Subnet avrox.IPPrefix
+	}
+	schema := avro.MustParse(`{"type":"record","name":"Telemetry","fields":[
+		{"name":"Source","type":{"type":"record","name":"IPAddr","fields":[
+			{"name":"V4","type":["null",{"type":"fixed","name":"IPv4_4","size":4}]},
+			{"name":"V6","type":["null",{"type":"fixed","name":"IPv6_16","size":16}]},
+			{"name":"Zone","type":"string"}]}},
+		{"name":"Subnet","type":{"type":"record","name":"IPPrefix","fields":[
+			{"name":"Addr","type":"IPAddr"},{"name":"Bits","type":"int"}]}}]}`)
+	in := Telemetry{
+		Source: avrox.NewIPAddr(netip.MustParseAddr("10.1.2.3")),
+		Subnet: avrox.NewIPPrefix(netip.MustParsePrefix("10.1.0.0/16")),
+	}
+	data, err := avro.Marshal(schema, in)
+	assert.NoError(t, err)
+	var out Telemetry
+	assert.NoError(t, avro.Unmarshal(schema, data, &out))
+	assert.Equal(t, netip.MustParseAddr("10.1.2.3"), out.Source.Addr())
+	assert.Equal(t, netip.MustParsePrefix("10.1.0.0/16"), out.Subnet.Prefix())
+}
+
+type testOrder struct {
+	Magic [avrox.MagicLen]byte
+	ID    string
+}
+
+func (testOrder) Schema() string {
+	return `{"type":"record","name":"Order","fields":[
+		{"name":"Magic","type":{"name":"Magic_8","size":8,"type":"fixed"}},{"name":"ID","type":"string"}]}`
+}
+
+func (testOrder) NamespaceID() avrox.NamespaceID {
+	return avrox.NamespacePrivate
+}
+
+func (testOrder) SchemaID() avrox.SchemaID {
+	return avrox.PackSchemVer(7, 1)
+}
+
+func TestEnvelope(t *testing.T) {
+	registry, err := avrox.NewRegistry((*testOrder)(nil))
+	assert.NoError(t, err)
+	assert.ErrorIs(t, registry.Register(&testOrder{}), avrox.ErrSchemerRegistered)
+	assert.ErrorIs(t, registry.Register(&avrox.BasicString{}), avrox.ErrSchemerRegistered)
+	assert.ErrorIs(t, registry.Register(nil), avrox.ErrNoPointerDestination)
+
+	// the zero value is usable
+	var zero avrox.Registry
+	assert.NoError(t, zero.Register((*testOrder)(nil)))
+	_, _, found := zero.New(avrox.NamespacePrivate, avrox.PackSchemVer(7, 1))
+	assert.True(t, found)
+
+	meta := avrox.Meta{
+		ProducerID:    "billing",
+		EventTime:     time.Date(2024, 5, 1, 8, 0, 0, 123456000, time.UTC),
+		TraceID:       "4bf92f3577b34da6a3ce929d0e0e4736",
+		SpanID:        "00f067aa0ba902b7",
+		CorrelationID: "order-42",
+		Values:        map[string]string{"tenant": "acme"},
+	}
+	inner := &testOrder{ID: "inner"}
+	data, err := avrox.Wrap(inner, meta, avrox.CompSnappy)
+	assert.NoError(t, err)
+
+	n, s, c, err := avrox.DecodeMagic(data[:avrox.MagicLen])
+	assert.NoError(t, err)
+	assert.Equal(t, avrox.NamespaceBasic, n)
+	assert.Equal(t, avrox.BasicEnvelopeSchemaID, s)
+	assert.Equal(t, avrox.CompNone, c)
+
+	gotMeta, gotInner, err := avrox.Unwrap(data, registry)
+	assert.NoError(t, err)
+	assert.Equal(t, meta, gotMeta)
+	assert.Equal(t, "inner", gotInner.(*testOrder).ID)
+
+	_, _, err = avrox.Unwrap(data, nil)
+	assert.ErrorIs(t, err, avrox.ErrSchemerNotFound)
+
+	// basic payloads do not need a registry
+	payload, err := avrox.MarshalBasic("hello", avrox.CompNone)
+	assert.NoError(t, err)
+	data, err = avrox.WrapData(payload, avrox.Meta{ProducerID: "greeter"})
+	assert.NoError(t, err)
+	gotMeta, gotInner, err = avrox.Unwrap(data, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, avrox.Meta{ProducerID: "greeter"}, gotMeta)
+	assert.True(t, gotMeta.EventTime.IsZero())
+	assert.Equal(t, "hello", gotInner.(*avrox.BasicString).Value)
+
+	_, err = avrox.WrapData([]byte("no avrox"), meta)
+	assert.ErrorIs(t, err, avrox.ErrNotAvroX)
+}
+
+func TestBatch(t *testing.T) {
+	items := make([]avrox.BasicMapStringAny, 200)
+	for i := range items {
+		items[i].Value = map[string]any{"sensor": "temperature", "unit": "celsius", "seq": i, "value": 21.5}
+	}
+	data, err := avrox.MarshalBatch(items, avrox.CompSnappy)
+	assert.NoError(t, err)
+	assert.True(t, avrox.IsBatch(data))
+
+	// a single message decoder does not read a batch by accident
+	_, err = avrox.UnmarshalBasic(data)
+	assert.ErrorIs(t, err, avrox.ErrNoBasicSchema)
+
+	decoded, err := avrox.UnmarshalBatch[avrox.BasicMapStringAny](data)
+	assert.NoError(t, err)
+	assert.Len(t, decoded, len(items))
+	for i := range items {
+		assert.Equal(t, items[i].Value, decoded[i].Value)
+	}
+
+	// compressing the batch is a lot smaller than compressing each message
+	single := 0
+	for i := range items {
+		msg, errMarshal := avrox.Marshal(&items[i], avrox.CompSnappy, nil)
+		assert.NoError(t, errMarshal)
+		single += len(msg)
+	}
+	assert.Less(t, len(data)*3, single)
+
+	var seen []int
+	stop := errors.New("stop")
+	err = avrox.IterateBatch[avrox.BasicMapStringAny](data, func(idx int, item *avrox.BasicMapStringAny) error {
+		seen = append(seen, item.Value["seq"].(int))
+		if idx == 2 {
+			return stop
+		}
+		return nil
+	})
+	assert.ErrorIs(t, err, stop)
+	assert.Equal(t, []int{0, 1, 2}, seen)
+
+	batch, err := avrox.MarshalBatch([]avrox.BasicString{{Value: "a"}, {Value: "b"}}, avrox.CompNone)
+	assert.NoError(t, err)
+	values, err := avrox.UnmarshalBatch[avrox.BasicString](batch)
+	assert.NoError(t, err)
+	assert.Equal(t, "b", values[1].Value)
+	_, err = avrox.UnmarshalBatch[avrox.BasicInt](batch)
+	assert.ErrorIs(t, err, avrox.ErrWrongSchema)
+	_, err = avrox.UnmarshalBatch[avrox.BasicString](append(batch, 0))
+	assert.ErrorIs(t, err, avrox.ErrBatchTrailingData)
+
+	assert.Equal(t, [avrox.MagicLen]byte(avrox.MustEncodeBasicMagic(avrox.BasicStringSchemaID, avrox.CompNone)), values[0].Magic)
+
+	// the batch is a BasicBatch which readers without MarshalBatch can decode. The element magic
+	// is stored once and the items only add their fields (a string of length 1 takes 2 bytes)
+	var container avrox.BasicBatch
+	assert.NoError(t, avrox.Unmarshal(batch, &container, nil))
+	assert.Equal(t, 2, container.Count)
+	assert.Equal(t, values[0].Magic, container.Element)
+	assert.Equal(t, []byte{2, 'a', 2, 'b'}, container.Bodies)
+	assert.Len(t, batch, 2*avrox.MagicLen+1+1+4)
+
+	// the items of the caller are not modified
+	items[0].Magic = [avrox.MagicLen]byte{}
+	_, err = avrox.MarshalBatch(items[:1], avrox.CompNone)
+	assert.NoError(t, err)
+	assert.Equal(t, [avrox.MagicLen]byte{}, items[0].Magic)
+
+	empty, err := avrox.MarshalBatch([]avrox.BasicString{}, avrox.CompGZip)
+	assert.NoError(t, err)
+	values, err = avrox.UnmarshalBatch[avrox.BasicString](empty)
+	assert.NoError(t, err)
+	assert.Empty(t, values)
+
+	message, _ := avrox.MarshalBasic("a", avrox.CompNone)
+	_, err = avrox.UnmarshalBatch[avrox.BasicString](message)
+	assert.ErrorIs(t, err, avrox.ErrNoBatch)
+}
+
+func TestMarshalBasicRawDateDays(t *testing.T) {
+	for _, date := range []rawdate.RawDate{rawdate.Zero, rawdate.MustNew(1955, 11, 5), rawdate.MustNew(2024, 2, 29)} {
+		data, err := avrox.MarshalBasic(date, avrox.CompNone)
+		assert.NoError(t, err)
+		// magic and the avro date (zigzag varint)
+		assert.LessOrEqual(t, len(data), avrox.MagicLen+3)
+		result, err := avrox.UnmarshalBasicAs[rawdate.RawDate](data)
+		assert.NoError(t, err)
+		assert.Equal(t, date, result)
+
+		// readers without rawdate see a standard avro date
+		var generic struct {
+			Magic [avrox.MagicLen]byte
+			Value time.Time
+		}
+		assert.NoError(t, avro.Unmarshal(avro.MustParse(avrox.BasicRawDateAVSC), data, &generic))
+		assert.Equal(t, date.Time(time.UTC), generic.Value)
+
+		// the container itself works with plain hamba/avro
+		plain, err := avro.Marshal(avro.MustParse(avrox.BasicRawDateAVSC), avrox.BasicRawDate{Value: date.Days()})
+		assert.NoError(t, err)
+		var container avrox.BasicRawDate
+		assert.NoError(t, avro.Unmarshal(avro.MustParse(avrox.BasicRawDateAVSC), plain, &container))
+		assert.Equal(t, date, container.Value.RawDate())
+	}
+
+	// rawdate.RawDate fields can not be declared as avro date (the open part of the compact encoding)
+	_, err := avro.Marshal(avro.MustParse(avrox.BasicRawDateAVSC), avrox.BasicRawDateV1{Value: rawdate.MustNew(2024, 2, 29)})
+	assert.Error(t, err)
+
+	// the former record encoding still gets unmarshalled and can be migrated
+	old, err := avrox.Marshal(&avrox.BasicRawDateV1{Value: rawdate.MustNew(1899, 12, 30)}, avrox.CompSnappy, nil)
+	assert.NoError(t, err)
+	result, err := avrox.UnmarshalBasicAs[rawdate.RawDate](old)
+	assert.NoError(t, err)
+	assert.Equal(t, rawdate.MustNew(1899, 12, 30), result)
+	migrated, err := avrox.MigrateBasicRawDate(old)
+	assert.NoError(t, err)
+	_, s, c, err := avrox.DecodeMagic(migrated[:avrox.MagicLen])
+	assert.NoError(t, err)
+	assert.Equal(t, avrox.BasicRawDateSchemaID, s)
+	assert.Equal(t, avrox.CompSnappy, c)
+	result, err = avrox.UnmarshalBasicAs[rawdate.RawDate](migrated)
+	assert.NoError(t, err)
+	assert.Equal(t, rawdate.MustNew(1899, 12, 30), result)
+
+	// struct fields use rawdate.Days with the avro date logical type
+	type Person struct {
+		Birthday rawdate.Days
+	}
+	schema := avro.MustParse(`{"type":"record","name":"Person","fields":[
+		{"name":"Birthday","type":{"type":"int","logicalType":"date"}}]}`)
+	data, err := avro.Marshal(schema, Person{Birthday: rawdate.MustNew(1960, 7, 4).Days()})
+	assert.NoError(t, err)
+	var person Person
+	assert.NoError(t, avro.Unmarshal(schema, data, &person))
+	assert.Equal(t, rawdate.MustNew(1960, 7, 4), person.Birthday.RawDate())
+}
+
+func TestMarshalBasicDateRange(t *testing.T) {
+	r := rawdate.NewHalfOpenRange(rawdate.MustNew(2024, 1, 1), rawdate.MustNew(2024, 2, 1))
+	data, err := avrox.MarshalBasic(r, avrox.CompNone)
+	assert.NoError(t, err)
+	result, err := avrox.UnmarshalDateRange(data)
+	assert.NoError(t, err)
+	assert.Equal(t, r, result)
+
+	s := rawdate.NewRangeSet(
+		rawdate.NewRange(rawdate.MustNew(2024, 3, 1), rawdate.MustNew(2024, 3, 31)),
+		rawdate.NewRange(rawdate.MustNew(1960, 1, 1), rawdate.MustNew(1960, 12, 31)),
+	)
+	data, err = avrox.MarshalBasic(s, avrox.CompSnappy)
+	assert.NoError(t, err)
+	set, err := avrox.UnmarshalDateRangeSet(data)
+	assert.NoError(t, err)
+	assert.Equal(t, s, set)
+
+	data, err = avrox.MarshalBasic(rawdate.RangeSet{}, avrox.CompNone)
+	assert.NoError(t, err)
+	set, err = avrox.UnmarshalDateRangeSet(data)
+	assert.NoError(t, err)
+	assert.True(t, set.IsEmpty())
+
+	// DateRange can be used in own records
+	type Booking struct {
+		Period avrox.DateRange
+	}
+	schema := avro.MustParse(`{"type":"record","name":"Booking","fields":[
+		{"name":"Period","type":{"type":"record","name":"DateRange","fields":[
+			{"name":"Start","type":{"type":"int","logicalType":"date"}},
+			{"name":"End","type":{"type":"int","logicalType":"date"}},
+			{"name":"HalfOpen","type":"boolean"}]}}]}`)
+	data, err = avro.Marshal(schema, Booking{Period: avrox.NewDateRange(r)})
+	assert.NoError(t, err)
+	var booking Booking
+	assert.NoError(t, avro.Unmarshal(schema, data, &booking))
+	assert.Equal(t, r, booking.Period.Range())
+}
+
+func TestMarshalBasicRecurrence(t *testing.T) {
+	r := rawdate.MustParseRecurrence(rawdate.MustNew(2024, 3, 1), "FREQ=MONTHLY;INTERVAL=3;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1")
+	data, err := avrox.MarshalBasic(r, avrox.CompNone)
+	assert.NoError(t, err)
+	result, err := avrox.UnmarshalRecurrence(data)
+	assert.NoError(t, err)
+	assert.Equal(t, r, result)
+	next, ok := result.After(rawdate.MustNew(2024, 4, 1), false)
+	assert.True(t, ok)
+	assert.Equal(t, rawdate.MustNew(2024, 6, 28), next)
+
+	_, err = avrox.MarshalBasic(rawdate.Recurrence{Freq: "HOURLY"}, avrox.CompNone)
+	assert.ErrorIs(t, err, rawdate.ErrRecurrence)
+
+	data, err = avrox.Marshal(&avrox.BasicRecurrence{Rule: "FREQ=SOMETIMES"}, avrox.CompNone, nil)
+	assert.NoError(t, err)
+	_, err = avrox.UnmarshalRecurrence(data)
+	assert.ErrorIs(t, err, rawdate.ErrRecurrence)
+}
+
+func TestMarshalBasicNullRawDate(t *testing.T) {
+	for _, n := range []rawdate.NullRawDate{
+		rawdate.NewNullRawDate(rawdate.MustNew(2024, 2, 29)),
+		rawdate.NewNullRawDate(rawdate.Zero),
+		{},
+	} {
+		data, err := avrox.MarshalBasic(n, avrox.CompNone)
+		assert.NoError(t, err)
+		result, err := avrox.UnmarshalNullRawDate(data)
+		assert.NoError(t, err)
+		assert.Equal(t, n, result)
+	}
+
+	// the zero convention makes the zero RawDate null, like it is in JSON and SQL
+	data, err := avrox.MarshalBasic(rawdate.ZeroIsNull.Nullable(rawdate.Zero), avrox.CompNone)
+	assert.NoError(t, err)
+	result, err := avrox.UnmarshalNullRawDate(data)
+	assert.NoError(t, err)
+	assert.False(t, result.Valid)
+	assert.Equal(t, rawdate.Zero, rawdate.ZeroIsNull.RawDate(result))
+
+	// *rawdate.Days can be used for optional dates in own records
+	type Contract struct {
+		Ends *rawdate.Days
+	}
+	schema := avro.MustParse(`{"type":"record","name":"Contract","fields":[
+		{"name":"Ends","type":["null",{"type":"int","logicalType":"date"}]}]}`)
+	for _, n := range []rawdate.NullRawDate{rawdate.NewNullRawDate(rawdate.MustNew(2030, 12, 31)), {}} {
+		data, err = avro.Marshal(schema, Contract{Ends: n.Days()})
+		assert.NoError(t, err)
+		var contract Contract
+		assert.NoError(t, avro.Unmarshal(schema, data, &contract))
+		assert.Equal(t, n, rawdate.NullRawDateFromDays(contract.Ends))
+	}
+}
+
+func TestMarshalBasicRawTime(t *testing.T) {
+	rt := rawdate.MustNewTime(9, 30, 15, 123_456_000)
+	data, err := avrox.MarshalBasic(rt, avrox.CompNone)
+	assert.NoError(t, err)
+	result, err := avrox.UnmarshalRawTime(data)
+	assert.NoError(t, err)
+	assert.Equal(t, rt, result)
+
+	// stored as microseconds
+	data, err = avrox.MarshalBasic(rawdate.MustNewTime(9, 30, 15, 123_456_789), avrox.CompNone)
+	assert.NoError(t, err)
+	result, err = avrox.UnmarshalRawTime(data)
+	assert.NoError(t, err)
+	assert.Equal(t, rt, result)
+
+	dt := rawdate.MustNewDateTime(1960, time.February, 29, 23, 59, 59, 999_999_000)
+	data, err = avrox.MarshalBasic(dt, avrox.CompSnappy)
+	assert.NoError(t, err)
+	resultDT, err := avrox.UnmarshalRawDateTime(data)
+	assert.NoError(t, err)
+	assert.Equal(t, dt, resultDT)
+
+	// invalid values are rejected in both directions
+	_, err = avrox.MarshalBasic(rawdate.RawTime{Hour: 30}, avrox.CompNone)
+	assert.ErrorIs(t, err, avrox.ErrRawTimeInvalid)
+	data, err = avrox.Marshal(&avrox.BasicRawTime{Value: 25 * time.Hour}, avrox.CompNone, nil)
+	assert.NoError(t, err)
+	_, err = avrox.UnmarshalRawTime(data)
+	assert.ErrorIs(t, err, avrox.ErrRawTimeInvalid)
+	data, err = avrox.Marshal(&avrox.BasicRawTime{Value: -time.Minute}, avrox.CompNone, nil)
+	assert.NoError(t, err)
+	_, err = avrox.UnmarshalRawTime(data)
+	assert.ErrorIs(t, err, avrox.ErrRawTimeInvalid)
+	_, err = avrox.MarshalBasic(rawdate.RawDateTime{Date: dt.Date, Clock: rawdate.RawTime{Minute: 61}}, avrox.CompNone)
+	assert.ErrorIs(t, err, avrox.ErrRawDateTimeInvalid)
+
+	// time.Duration and rawdate.LocalMillis can be used in own records
+	type Shift struct {
+		Starts time.Duration
+		Since  rawdate.LocalMillis
+	}
+	schema := avro.MustParse(`{"type":"record","name":"Shift","fields":[
+		{"name":"Starts","type":{"type":"int","logicalType":"time-millis"}},
+		{"name":"Since","type":{"type":"long","logicalType":"local-timestamp-millis"}}]}`)
+	starts := rawdate.MustNewTime(6, 0, 0, 0)
+	since := rawdate.MustNewDateTime(2024, time.October, 27, 2, 30, 0, 0)
+	data, err = avro.Marshal(schema, Shift{Starts: starts.Duration(), Since: since.LocalMillis()})
+	assert.NoError(t, err)
+	var shift Shift
+	assert.NoError(t, avro.Unmarshal(schema, data, &shift))
+	assert.Equal(t, starts, rawdate.TimeFromDuration(shift.Starts))
+	assert.Equal(t, since, shift.Since.RawDateTime())
+}
+
+func TestAvroNow(t *testing.T) {
+	clock := rawdate.NewFakeClock(time.Date(2024, 3, 31, 23, 30, 15, 123_456_789, time.FixedZone("EDT", -4*3600)))
+	defer rawdate.SetClock(clock)()
+	assert.Equal(t, time.Date(2024, 4, 1, 3, 30, 15, 123_000_000, time.UTC), avrox.AvroNow())
+	assert.Equal(t, time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC), avrox.AvroToday(time.FixedZone("EDT", -4*3600)))
+	assert.Equal(t, time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC), avrox.AvroToday(time.UTC))
+}
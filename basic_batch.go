@@ -0,0 +1,38 @@
+package avrox
+
+import (
+	_ "embed"
+)
+
+// Implementation of BasicBatch
+var _ Schemer = (*BasicBatch)(nil)
+
+// BasicBatch is the container type to store many avrox messages of one schema (compressed together) in a single avro schema.
+// Element is the magic of the items (without compression), Count the number of items and Bodies the
+// concatenated avro encoding of the items without their magic field. Use MarshalBatch and IterateBatch
+// instead of filling it directly (plain avro readers limit the size of Bodies to their MaxByteSliceSize).
+type BasicBatch struct {
+	Magic   [MagicLen]byte // 1.40.1
+	Element [MagicLen]byte
+	Count   int
+	Bodies  []byte
+}
+
+//go:generate avscgen -n "basics" -o avsc/ . BasicBatch
+//go:embed avsc/basic_batch.avsc
+var BasicBatchAVSC string
+
+// Schema returns the AVRO schema for the BasicBatch struct type
+func (BasicBatch) Schema() string {
+	return BasicBatchAVSC
+}
+
+// NamespaceID returns the namespace id for the BasicBatch struct type
+func (BasicBatch) NamespaceID() NamespaceID {
+	return NamespaceBasic
+}
+
+// SchemaID returns the schema id for the BasicBatch struct type
+func (BasicBatch) SchemaID() SchemaID {
+	return BasicBatchSchemaID
+}
@@ -0,0 +1,31 @@
+package avrox
+
+import _ "embed"
+
+// Implementation of BasicBool
+var _ Schemer = (*BasicBool)(nil)
+
+// BasicBool is the container type to store a bool in a single avro schema
+type BasicBool struct {
+	Magic [MagicLen]byte // 1.8.1
+	Value bool
+}
+
+//go:generate avscgen -n "basics" -o avsc/ . BasicBool
+//go:embed avsc/basic_bool.avsc
+var BasicBoolAVSC string
+
+// Schema returns the AVRO schema for the BasicBool struct type
+func (BasicBool) Schema() string {
+	return BasicBoolAVSC
+}
+
+// NamespaceID returns the namespace id for the BasicBool struct type
+func (BasicBool) NamespaceID() NamespaceID {
+	return NamespaceBasic
+}
+
+// SchemaID returns the schema id for the BasicBool struct type
+func (BasicBool) SchemaID() SchemaID {
+	return BasicBoolSchemaID
+}
@@ -0,0 +1,31 @@
+package avrox
+
+import _ "embed"
+
+// Implementation of BasicDateRange
+var _ Schemer = (*BasicDateRange)(nil)
+
+// BasicDateRange is the container type to store a rawdate.Range (closed or half-open period of dates) in a single avro schema
+type BasicDateRange struct {
+	Magic [MagicLen]byte // 1.34.1
+	Value DateRange
+}
+
+//go:generate avscgen -n "basics" -o avsc/ . BasicDateRange
+//go:embed avsc/basic_date_range.avsc
+var BasicDateRangeAVSC string
+
+// Schema returns the AVRO schema for the BasicDateRange struct type
+func (BasicDateRange) Schema() string {
+	return BasicDateRangeAVSC
+}
+
+// NamespaceID returns the namespace id for the BasicDateRange struct type
+func (BasicDateRange) NamespaceID() NamespaceID {
+	return NamespaceBasic
+}
+
+// SchemaID returns the schema id for the BasicDateRange struct type
+func (BasicDateRange) SchemaID() SchemaID {
+	return BasicDateRangeSchemaID
+}
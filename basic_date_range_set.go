@@ -0,0 +1,31 @@
+package avrox
+
+import _ "embed"
+
+// Implementation of BasicDateRangeSet
+var _ Schemer = (*BasicDateRangeSet)(nil)
+
+// BasicDateRangeSet is the container type to store a rawdate.RangeSet (normalized closed periods of dates) in a single avro schema
+type BasicDateRangeSet struct {
+	Magic [MagicLen]byte // 1.35.1
+	Value []DateRange
+}
+
+//go:generate avscgen -n "basics" -o avsc/ . BasicDateRangeSet
+//go:embed avsc/basic_date_range_set.avsc
+var BasicDateRangeSetAVSC string
+
+// Schema returns the AVRO schema for the BasicDateRangeSet struct type
+func (BasicDateRangeSet) Schema() string {
+	return BasicDateRangeSetAVSC
+}
+
+// NamespaceID returns the namespace id for the BasicDateRangeSet struct type
+func (BasicDateRangeSet) NamespaceID() NamespaceID {
+	return NamespaceBasic
+}
+
+// SchemaID returns the schema id for the BasicDateRangeSet struct type
+func (BasicDateRangeSet) SchemaID() SchemaID {
+	return BasicDateRangeSetSchemaID
+}
@@ -8,6 +8,13 @@ import (
 // Implementation of BasicDecimal
 var _ Schemer = (*BasicDecimal)(nil)
 
+// The precision and scale of the BasicDecimal schema. Values get truncated to the
+// scale by hamba/avro (see MarshalDecimalStrict and BasicDecimalScaled).
+const (
+	BasicDecimalPrecision = 17
+	BasicDecimalScale     = 4
+)
+
 // BasicDecimal is the container type to store a *bigRat value into a single avro schema
 type BasicDecimal struct {
 	Magic [MagicLen]byte // 1.6.1
@@ -30,5 +37,5 @@ func (BasicDecimal) NamespaceID() NamespaceID {
 
 // SchemaID returns the schema id for the BasicDecimal struct type
 func (BasicDecimal) SchemaID() SchemaID {
-	return BasicTimeSchemaID
+	return BasicDecimalSchemaID
 }
@@ -0,0 +1,51 @@
+package avrox
+
+import _ "embed"
+
+// Implementation of BasicDecimalScaled
+var _ Schemer = (*BasicDecimalScaled)(nil)
+
+// BasicDecimalScaled is the container type to store a Decimal (with its scale) in a single avro schema
+type BasicDecimalScaled struct {
+	Magic    [MagicLen]byte // 1.23.1
+	Unscaled []byte         // two's-complement big-endian (like the avro decimal)
+	Scale    int
+}
+
+//go:generate avscgen -n "basics" -o avsc/ . BasicDecimalScaled
+//go:embed avsc/basic_decimal_scaled.avsc
+var BasicDecimalScaledAVSC string
+
+// Schema returns the AVRO schema for the BasicDecimalScaled struct type
+func (BasicDecimalScaled) Schema() string {
+	return BasicDecimalScaledAVSC
+}
+
+// NamespaceID returns the namespace id for the BasicDecimalScaled struct type
+func (BasicDecimalScaled) NamespaceID() NamespaceID {
+	return NamespaceBasic
+}
+
+// SchemaID returns the schema id for the BasicDecimalScaled struct type
+func (BasicDecimalScaled) SchemaID() SchemaID {
+	return BasicDecimalScaledSchemaID
+}
+
+// Decimal returns the stored value. It fails with ErrDecimalScale for a scale outside
+// 0..MaxDecimalScale (which corrupt data could use to make the conversions expensive).
+func (b BasicDecimalScaled) Decimal() (Decimal, error) {
+	if b.Scale < 0 || b.Scale > MaxDecimalScale {
+		return Decimal{}, ErrDecimalScale
+	}
+	return Decimal{Unscaled: bytesInt(b.Unscaled), Scale: b.Scale}, nil
+}
+
+// SetDecimal stores the value. It fails with ErrDecimalScale for a scale outside 0..MaxDecimalScale.
+func (b *BasicDecimalScaled) SetDecimal(d Decimal) error {
+	if d.Scale < 0 || d.Scale > MaxDecimalScale {
+		return ErrDecimalScale
+	}
+	b.Unscaled = intBytes(d.unscaled())
+	b.Scale = d.Scale
+	return nil
+}
@@ -0,0 +1,34 @@
+package avrox
+
+import (
+	_ "embed"
+	"time"
+)
+
+// Implementation of BasicDuration
+var _ Schemer = (*BasicDuration)(nil)
+
+// BasicDuration is the container type to store a time.Duration (as nanoseconds) in a single avro schema
+type BasicDuration struct {
+	Magic [MagicLen]byte // 1.13.1
+	Value time.Duration
+}
+
+//go:generate avscgen -n "basics" -o avsc/ . BasicDuration
+//go:embed avsc/basic_duration.avsc
+var BasicDurationAVSC string
+
+// Schema returns the AVRO schema for the BasicDuration struct type
+func (BasicDuration) Schema() string {
+	return BasicDurationAVSC
+}
+
+// NamespaceID returns the namespace id for the BasicDuration struct type
+func (BasicDuration) NamespaceID() NamespaceID {
+	return NamespaceBasic
+}
+
+// SchemaID returns the schema id for the BasicDuration struct type
+func (BasicDuration) SchemaID() SchemaID {
+	return BasicDurationSchemaID
+}
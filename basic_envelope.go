@@ -0,0 +1,40 @@
+package avrox
+
+import (
+	_ "embed"
+	"time"
+)
+
+// Implementation of BasicEnvelope
+var _ Schemer = (*BasicEnvelope)(nil)
+
+// BasicEnvelope is the container type to store metadata and a nested avrox message in a single avro schema
+type BasicEnvelope struct {
+	Magic         [MagicLen]byte // 1.33.1
+	ProducerID    string
+	EventTime     time.Time // timestamp-micros
+	TraceID       string
+	SpanID        string
+	CorrelationID string
+	Meta          map[string]string
+	Value         []byte // a complete avrox message (with its own magic)
+}
+
+//go:generate avscgen -n "basics" -o avsc/ . BasicEnvelope
+//go:embed avsc/basic_envelope.avsc
+var BasicEnvelopeAVSC string
+
+// Schema returns the AVRO schema for the BasicEnvelope struct type
+func (BasicEnvelope) Schema() string {
+	return BasicEnvelopeAVSC
+}
+
+// NamespaceID returns the namespace id for the BasicEnvelope struct type
+func (BasicEnvelope) NamespaceID() NamespaceID {
+	return NamespaceBasic
+}
+
+// SchemaID returns the schema id for the BasicEnvelope struct type
+func (BasicEnvelope) SchemaID() SchemaID {
+	return BasicEnvelopeSchemaID
+}
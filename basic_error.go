@@ -0,0 +1,46 @@
+package avrox
+
+import _ "embed"
+
+// Implementation of BasicError
+var _ Schemer = (*BasicError)(nil)
+
+// BasicError is the container type to transport an error (tree) in a single avro schema.
+// The wrapped causes are stored flat (in depth first order) and reference their parent.
+// Use NewBasicError to create it from a go error and RemoteError to get it back.
+type BasicError struct {
+	Magic   [MagicLen]byte // 1.22.1
+	Code    string
+	Message string
+	Details map[string]string
+	NSV     string // The N.S.V of the message which failed (if any)
+	Causes  []ErrorCause
+}
+
+// ErrorCause is a wrapped error inside a BasicError
+type ErrorCause struct {
+	Parent  int // -1 for the top level error, otherwise the index into the causes
+	Code    string
+	Message string
+	Details map[string]string
+	NSV     string
+}
+
+//go:generate avscgen -n "basics" -o avsc/ . BasicError
+//go:embed avsc/basic_error.avsc
+var BasicErrorAVSC string
+
+// Schema returns the AVRO schema for the BasicError struct type
+func (BasicError) Schema() string {
+	return BasicErrorAVSC
+}
+
+// NamespaceID returns the namespace id for the BasicError struct type
+func (BasicError) NamespaceID() NamespaceID {
+	return NamespaceBasic
+}
+
+// SchemaID returns the schema id for the BasicError struct type
+func (BasicError) SchemaID() SchemaID {
+	return BasicErrorSchemaID
+}
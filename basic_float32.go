@@ -0,0 +1,31 @@
+package avrox
+
+import _ "embed"
+
+// Implementation of BasicFloat32
+var _ Schemer = (*BasicFloat32)(nil)
+
+// BasicFloat32 is the container type to store a float32 in a single avro schema
+type BasicFloat32 struct {
+	Magic [MagicLen]byte // 1.11.1
+	Value float32
+}
+
+//go:generate avscgen -n "basics" -o avsc/ . BasicFloat32
+//go:embed avsc/basic_float32.avsc
+var BasicFloat32AVSC string
+
+// Schema returns the AVRO schema for the BasicFloat32 struct type
+func (BasicFloat32) Schema() string {
+	return BasicFloat32AVSC
+}
+
+// NamespaceID returns the namespace id for the BasicFloat32 struct type
+func (BasicFloat32) NamespaceID() NamespaceID {
+	return NamespaceBasic
+}
+
+// SchemaID returns the schema id for the BasicFloat32 struct type
+func (BasicFloat32) SchemaID() SchemaID {
+	return BasicFloat32SchemaID
+}
@@ -0,0 +1,31 @@
+package avrox
+
+import _ "embed"
+
+// Implementation of BasicFloat64
+var _ Schemer = (*BasicFloat64)(nil)
+
+// BasicFloat64 is the container type to store a float64 in a single avro schema
+type BasicFloat64 struct {
+	Magic [MagicLen]byte // 1.12.1
+	Value float64
+}
+
+//go:generate avscgen -n "basics" -o avsc/ . BasicFloat64
+//go:embed avsc/basic_float64.avsc
+var BasicFloat64AVSC string
+
+// Schema returns the AVRO schema for the BasicFloat64 struct type
+func (BasicFloat64) Schema() string {
+	return BasicFloat64AVSC
+}
+
+// NamespaceID returns the namespace id for the BasicFloat64 struct type
+func (BasicFloat64) NamespaceID() NamespaceID {
+	return NamespaceBasic
+}
+
+// SchemaID returns the schema id for the BasicFloat64 struct type
+func (BasicFloat64) SchemaID() SchemaID {
+	return BasicFloat64SchemaID
+}
@@ -0,0 +1,31 @@
+package avrox
+
+import _ "embed"
+
+// Implementation of BasicFloat64Slice
+var _ Schemer = (*BasicFloat64Slice)(nil)
+
+// BasicFloat64Slice is the container type to store a []float64 in a single avro schema
+type BasicFloat64Slice struct {
+	Magic [MagicLen]byte // 1.18.1
+	Value []float64
+}
+
+//go:generate avscgen -n "basics" -o avsc/ . BasicFloat64Slice
+//go:embed avsc/basic_float64_slice.avsc
+var BasicFloat64SliceAVSC string
+
+// Schema returns the AVRO schema for the BasicFloat64Slice struct type
+func (BasicFloat64Slice) Schema() string {
+	return BasicFloat64SliceAVSC
+}
+
+// NamespaceID returns the namespace id for the BasicFloat64Slice struct type
+func (BasicFloat64Slice) NamespaceID() NamespaceID {
+	return NamespaceBasic
+}
+
+// SchemaID returns the schema id for the BasicFloat64Slice struct type
+func (BasicFloat64Slice) SchemaID() SchemaID {
+	return BasicFloat64SliceSchemaID
+}
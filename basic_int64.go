@@ -0,0 +1,31 @@
+package avrox
+
+import _ "embed"
+
+// Implementation of BasicInt64
+var _ Schemer = (*BasicInt64)(nil)
+
+// BasicInt64 is the container type to store an int64 in a single avro schema
+type BasicInt64 struct {
+	Magic [MagicLen]byte // 1.9.1
+	Value int64
+}
+
+//go:generate avscgen -n "basics" -o avsc/ . BasicInt64
+//go:embed avsc/basic_int64.avsc
+var BasicInt64AVSC string
+
+// Schema returns the AVRO schema for the BasicInt64 struct type
+func (BasicInt64) Schema() string {
+	return BasicInt64AVSC
+}
+
+// NamespaceID returns the namespace id for the BasicInt64 struct type
+func (BasicInt64) NamespaceID() NamespaceID {
+	return NamespaceBasic
+}
+
+// SchemaID returns the schema id for the BasicInt64 struct type
+func (BasicInt64) SchemaID() SchemaID {
+	return BasicInt64SchemaID
+}
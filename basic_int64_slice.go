@@ -0,0 +1,31 @@
+package avrox
+
+import _ "embed"
+
+// Implementation of BasicInt64Slice
+var _ Schemer = (*BasicInt64Slice)(nil)
+
+// BasicInt64Slice is the container type to store an []int64 in a single avro schema
+type BasicInt64Slice struct {
+	Magic [MagicLen]byte // 1.17.1
+	Value []int64
+}
+
+//go:generate avscgen -n "basics" -o avsc/ . BasicInt64Slice
+//go:embed avsc/basic_int64_slice.avsc
+var BasicInt64SliceAVSC string
+
+// Schema returns the AVRO schema for the BasicInt64Slice struct type
+func (BasicInt64Slice) Schema() string {
+	return BasicInt64SliceAVSC
+}
+
+// NamespaceID returns the namespace id for the BasicInt64Slice struct type
+func (BasicInt64Slice) NamespaceID() NamespaceID {
+	return NamespaceBasic
+}
+
+// SchemaID returns the schema id for the BasicInt64Slice struct type
+func (BasicInt64Slice) SchemaID() SchemaID {
+	return BasicInt64SliceSchemaID
+}
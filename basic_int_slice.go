@@ -0,0 +1,31 @@
+package avrox
+
+import _ "embed"
+
+// Implementation of BasicIntSlice
+var _ Schemer = (*BasicIntSlice)(nil)
+
+// BasicIntSlice is the container type to store an []int in a single avro schema
+type BasicIntSlice struct {
+	Magic [MagicLen]byte // 1.16.1
+	Value []int
+}
+
+//go:generate avscgen -n "basics" -o avsc/ . BasicIntSlice
+//go:embed avsc/basic_int_slice.avsc
+var BasicIntSliceAVSC string
+
+// Schema returns the AVRO schema for the BasicIntSlice struct type
+func (BasicIntSlice) Schema() string {
+	return BasicIntSliceAVSC
+}
+
+// NamespaceID returns the namespace id for the BasicIntSlice struct type
+func (BasicIntSlice) NamespaceID() NamespaceID {
+	return NamespaceBasic
+}
+
+// SchemaID returns the schema id for the BasicIntSlice struct type
+func (BasicIntSlice) SchemaID() SchemaID {
+	return BasicIntSliceSchemaID
+}
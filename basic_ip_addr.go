@@ -0,0 +1,31 @@
+package avrox
+
+import _ "embed"
+
+// Implementation of BasicIPAddr
+var _ Schemer = (*BasicIPAddr)(nil)
+
+// BasicIPAddr is the container type to store a netip.Addr (as 4 or 16 fixed bytes) in a single avro schema
+type BasicIPAddr struct {
+	Magic [MagicLen]byte // 1.29.1
+	Value IPAddr
+}
+
+//go:generate avscgen -n "basics" -o avsc/ . BasicIPAddr
+//go:embed avsc/basic_ip_addr.avsc
+var BasicIPAddrAVSC string
+
+// Schema returns the AVRO schema for the BasicIPAddr struct type
+func (BasicIPAddr) Schema() string {
+	return BasicIPAddrAVSC
+}
+
+// NamespaceID returns the namespace id for the BasicIPAddr struct type
+func (BasicIPAddr) NamespaceID() NamespaceID {
+	return NamespaceBasic
+}
+
+// SchemaID returns the schema id for the BasicIPAddr struct type
+func (BasicIPAddr) SchemaID() SchemaID {
+	return BasicIPAddrSchemaID
+}
@@ -0,0 +1,31 @@
+package avrox
+
+import _ "embed"
+
+// Implementation of BasicIPPrefix
+var _ Schemer = (*BasicIPPrefix)(nil)
+
+// BasicIPPrefix is the container type to store a netip.Prefix (address and prefix length) in a single avro schema
+type BasicIPPrefix struct {
+	Magic [MagicLen]byte // 1.30.1
+	Value IPPrefix
+}
+
+//go:generate avscgen -n "basics" -o avsc/ . BasicIPPrefix
+//go:embed avsc/basic_ip_prefix.avsc
+var BasicIPPrefixAVSC string
+
+// Schema returns the AVRO schema for the BasicIPPrefix struct type
+func (BasicIPPrefix) Schema() string {
+	return BasicIPPrefixAVSC
+}
+
+// NamespaceID returns the namespace id for the BasicIPPrefix struct type
+func (BasicIPPrefix) NamespaceID() NamespaceID {
+	return NamespaceBasic
+}
+
+// SchemaID returns the schema id for the BasicIPPrefix struct type
+func (BasicIPPrefix) SchemaID() SchemaID {
+	return BasicIPPrefixSchemaID
+}
@@ -0,0 +1,99 @@
+package avrox
+
+import (
+	_ "embed"
+	"reflect"
+)
+
+// Implementation of BasicList
+var _ Schemer = (*BasicList)(nil)
+
+// BasicList is the container type to store a list of avrox messages (each with its own magic) in a single avro schema
+type BasicList struct {
+	Magic [MagicLen]byte // 1.21.1
+	Value List
+}
+
+//go:generate avscgen -n "basics" -o avsc/ . BasicList
+//go:embed avsc/basic_list.avsc
+var BasicListAVSC string
+
+// Schema returns the AVRO schema for the BasicList struct type
+func (BasicList) Schema() string {
+	return BasicListAVSC
+}
+
+// NamespaceID returns the namespace id for the BasicList struct type
+func (BasicList) NamespaceID() NamespaceID {
+	return NamespaceBasic
+}
+
+// SchemaID returns the schema id for the BasicList struct type
+func (BasicList) SchemaID() SchemaID {
+	return BasicListSchemaID
+}
+
+// List holds complete avrox messages (each with its own magic). It gets stored with BasicList.
+type List [][]byte
+
+// NewList marshals the schemers into a List
+func NewList(cID CompressionID, items ...Schemer) (List, error) {
+	list := make(List, 0, len(items))
+	for _, item := range items {
+		data, err := Marshal(item, cID, nil)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, data)
+	}
+	return list, nil
+}
+
+// Basics unmarshals all elements of the list as basic types
+func (l List) Basics() ([]any, error) {
+	values := make([]any, 0, len(l))
+	for _, data := range l {
+		v, err := UnmarshalBasic(data)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, v)
+	}
+	return values, nil
+}
+
+// Schemers unmarshals all elements of the list. The magic of an element selects
+// the schemer. Every element gets a new allocated value of the type of the schemer.
+func (l List) Schemers(schemers ...Schemer) ([]Schemer, error) {
+	values := make([]Schemer, 0, len(l))
+	for _, data := range l {
+		if len(data) < MagicLen {
+			return nil, ErrNotAvroX
+		}
+		nID, sID, _, err := DecodeMagic(data[:MagicLen])
+		if err != nil {
+			return nil, err
+		}
+		var value Schemer
+		for _, schemer := range schemers {
+			t := reflect.TypeOf(schemer)
+			if t == nil || t.Kind() != reflect.Ptr {
+				return nil, ErrNoPointerDestination
+			}
+			// a new value also works for nil pointers of the schemer type
+			candidate := reflect.New(t.Elem()).Interface().(Schemer)
+			if candidate.NamespaceID() == nID && candidate.SchemaID() == sID {
+				value = candidate
+				break
+			}
+		}
+		if value == nil {
+			return nil, ErrSchemerNotFound
+		}
+		if err = Unmarshal(data, value, nil); err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
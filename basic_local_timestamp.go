@@ -0,0 +1,31 @@
+package avrox
+
+import _ "embed"
+
+// Implementation of BasicLocalTimestamp
+var _ Schemer = (*BasicLocalTimestamp)(nil)
+
+// BasicLocalTimestamp is the container type to store a LocalTimestamp (wall clock without zone) in a single avro schema
+type BasicLocalTimestamp struct {
+	Magic [MagicLen]byte // 1.28.1
+	Value int64          // nanoseconds of the wall clock since 1970-01-01 00:00:00
+}
+
+//go:generate avscgen -n "basics" -o avsc/ . BasicLocalTimestamp
+//go:embed avsc/basic_local_timestamp.avsc
+var BasicLocalTimestampAVSC string
+
+// Schema returns the AVRO schema for the BasicLocalTimestamp struct type
+func (BasicLocalTimestamp) Schema() string {
+	return BasicLocalTimestampAVSC
+}
+
+// NamespaceID returns the namespace id for the BasicLocalTimestamp struct type
+func (BasicLocalTimestamp) NamespaceID() NamespaceID {
+	return NamespaceBasic
+}
+
+// SchemaID returns the schema id for the BasicLocalTimestamp struct type
+func (BasicLocalTimestamp) SchemaID() SchemaID {
+	return BasicLocalTimestampSchemaID
+}
@@ -0,0 +1,31 @@
+package avrox
+
+import _ "embed"
+
+// Implementation of BasicMAC
+var _ Schemer = (*BasicMAC)(nil)
+
+// BasicMAC is the container type to store a net.HardwareAddr in a single avro schema
+type BasicMAC struct {
+	Magic [MagicLen]byte // 1.32.1
+	Value []byte
+}
+
+//go:generate avscgen -n "basics" -o avsc/ . BasicMAC
+//go:embed avsc/basic_mac.avsc
+var BasicMACAVSC string
+
+// Schema returns the AVRO schema for the BasicMAC struct type
+func (BasicMAC) Schema() string {
+	return BasicMACAVSC
+}
+
+// NamespaceID returns the namespace id for the BasicMAC struct type
+func (BasicMAC) NamespaceID() NamespaceID {
+	return NamespaceBasic
+}
+
+// SchemaID returns the schema id for the BasicMAC struct type
+func (BasicMAC) SchemaID() SchemaID {
+	return BasicMACSchemaID
+}
@@ -1,6 +1,11 @@
 package avrox
 
-import _ "embed"
+import (
+	_ "embed"
+	"reflect"
+
+	"github.com/hamba/avro/v2"
+)
 
 var _ Schemer = (*BasicMapStringAny)(nil)
 
@@ -8,8 +13,15 @@ var _ Schemer = (*BasicMapStringAny)(nil)
 //go:embed avsc/basic_map_string_any.avsc
 var BasicMapStringAnyAVSC string
 
+// BasicMapStringAny is the container type to store a map[string]any value into a single avro schema.
+// The values can be nil, bool, integers, floats, string, []byte, time.Time, *big.Rat and
+// nested maps (with string keys) or slices of those. After decoding, maps are map[string]any,
+// slices are []any, int is kept as int (when it fits into an avro int), other integers become
+// int64, time.Time is in UTC (with milliseconds) and decimals are *big.Rat (with a scale of 4).
+// Decimals which need more than 4 fractional digits or 17 digits fail with ErrDecimalInexact
+// or ErrDecimalOverflow (use a string or bytes for those).
 type BasicMapStringAny struct {
-	Magic [MagicLen]byte // 1.4.1
+	Magic [MagicLen]byte // 1.4.2
 	Value map[string]any
 }
 
@@ -27,3 +39,59 @@ func (BasicMapStringAny) NamespaceID() NamespaceID {
 func (BasicMapStringAny) SchemaID() SchemaID {
 	return BasicMapStringAnySchemaID
 }
+
+// encodeAvro writes the recursive AnyValue (hamba/avro can not do that)
+func (b BasicMapStringAny) encodeAvro(w *avro.Writer) {
+	writeAnyMap(w, reflect.ValueOf(b.Value))
+}
+
+// decodeAvro reads the recursive AnyValue
+func (b *BasicMapStringAny) decodeAvro(r *avro.Reader) {
+	b.Value = readAnyMap(r)
+}
+
+// Implementation of BasicMapStringAnyV1
+var _ Schemer = (*BasicMapStringAnyV1)(nil)
+
+// BasicMapStringAnyV1 is the former container type to store a map[string]any value (with a flat union)
+// into a single avro schema. UnmarshalBasic still reads it. Use MigrateBasicMapStringAny to convert the data.
+type BasicMapStringAnyV1 struct {
+	Magic [MagicLen]byte // 1.4.1
+	Value map[string]any
+}
+
+//go:generate avscgen -n "basics" -o avsc/ . BasicMapStringAnyV1
+//go:embed avsc/basic_map_string_any_v1.avsc
+var BasicMapStringAnyV1AVSC string
+
+// Schema returns the AVRO schema for the BasicMapStringAnyV1 struct type
+func (BasicMapStringAnyV1) Schema() string {
+	return BasicMapStringAnyV1AVSC
+}
+
+// NamespaceID returns the namespace id for the BasicMapStringAnyV1 struct type
+func (BasicMapStringAnyV1) NamespaceID() NamespaceID {
+	return NamespaceBasic
+}
+
+// SchemaID returns the schema id for the BasicMapStringAnyV1 struct type
+func (BasicMapStringAnyV1) SchemaID() SchemaID {
+	return BasicMapStringAnyV1SchemaID
+}
+
+// MigrateBasicMapStringAny converts BasicMapStringAnyV1 data to BasicMapStringAny (keeping the compression).
+// Other data is returned unchanged.
+func MigrateBasicMapStringAny(data []byte) ([]byte, error) {
+	if len(data) < MagicLen {
+		return data, nil
+	}
+	nID, sID, cID, err := DecodeMagic(data[:MagicLen])
+	if err != nil || nID != NamespaceBasic || sID != BasicMapStringAnyV1SchemaID {
+		return data, nil
+	}
+	old := &BasicMapStringAnyV1{}
+	if err = Unmarshal(data, old, basicSchema(BasicMapStringAnyV1SchemaID)); err != nil {
+		return nil, err
+	}
+	return Marshal(&BasicMapStringAny{Value: old.Value}, cID, basicSchema(BasicMapStringAnySchemaID))
+}
@@ -0,0 +1,31 @@
+package avrox
+
+import _ "embed"
+
+// Implementation of BasicMapStringInt
+var _ Schemer = (*BasicMapStringInt)(nil)
+
+// BasicMapStringInt is the container type to store a map[string]int in a single avro schema
+type BasicMapStringInt struct {
+	Magic [MagicLen]byte // 1.20.1
+	Value map[string]int
+}
+
+//go:generate avscgen -n "basics" -o avsc/ . BasicMapStringInt
+//go:embed avsc/basic_map_string_int.avsc
+var BasicMapStringIntAVSC string
+
+// Schema returns the AVRO schema for the BasicMapStringInt struct type
+func (BasicMapStringInt) Schema() string {
+	return BasicMapStringIntAVSC
+}
+
+// NamespaceID returns the namespace id for the BasicMapStringInt struct type
+func (BasicMapStringInt) NamespaceID() NamespaceID {
+	return NamespaceBasic
+}
+
+// SchemaID returns the schema id for the BasicMapStringInt struct type
+func (BasicMapStringInt) SchemaID() SchemaID {
+	return BasicMapStringIntSchemaID
+}
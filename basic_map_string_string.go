@@ -0,0 +1,31 @@
+package avrox
+
+import _ "embed"
+
+// Implementation of BasicMapStringString
+var _ Schemer = (*BasicMapStringString)(nil)
+
+// BasicMapStringString is the container type to store a map[string]string in a single avro schema
+type BasicMapStringString struct {
+	Magic [MagicLen]byte // 1.19.1
+	Value map[string]string
+}
+
+//go:generate avscgen -n "basics" -o avsc/ . BasicMapStringString
+//go:embed avsc/basic_map_string_string.avsc
+var BasicMapStringStringAVSC string
+
+// Schema returns the AVRO schema for the BasicMapStringString struct type
+func (BasicMapStringString) Schema() string {
+	return BasicMapStringStringAVSC
+}
+
+// NamespaceID returns the namespace id for the BasicMapStringString struct type
+func (BasicMapStringString) NamespaceID() NamespaceID {
+	return NamespaceBasic
+}
+
+// SchemaID returns the schema id for the BasicMapStringString struct type
+func (BasicMapStringString) SchemaID() SchemaID {
+	return BasicMapStringStringSchemaID
+}
@@ -0,0 +1,53 @@
+package avrox
+
+import _ "embed"
+
+// Implementation of BasicMoney
+var _ Schemer = (*BasicMoney)(nil)
+
+// BasicMoney is the container type to store Money (a decimal with its currency) in a single avro schema
+type BasicMoney struct {
+	Magic    [MagicLen]byte // 1.24.1
+	Unscaled []byte         // two's-complement big-endian (like the avro decimal)
+	Scale    int
+	Currency string // ISO 4217 code
+}
+
+//go:generate avscgen -n "basics" -o avsc/ . BasicMoney
+//go:embed avsc/basic_money.avsc
+var BasicMoneyAVSC string
+
+// Schema returns the AVRO schema for the BasicMoney struct type
+func (BasicMoney) Schema() string {
+	return BasicMoneyAVSC
+}
+
+// NamespaceID returns the namespace id for the BasicMoney struct type
+func (BasicMoney) NamespaceID() NamespaceID {
+	return NamespaceBasic
+}
+
+// SchemaID returns the schema id for the BasicMoney struct type
+func (BasicMoney) SchemaID() SchemaID {
+	return BasicMoneySchemaID
+}
+
+// Money returns the stored value. It fails if the value is not valid (see Money.Validate).
+func (b BasicMoney) Money() (Money, error) {
+	m := Money{Amount: Decimal{Unscaled: bytesInt(b.Unscaled), Scale: b.Scale}, Currency: b.Currency}
+	if err := m.Validate(); err != nil {
+		return Money{}, err
+	}
+	return m, nil
+}
+
+// SetMoney stores the value. It fails if the value is not valid (see Money.Validate).
+func (b *BasicMoney) SetMoney(m Money) error {
+	if err := m.Validate(); err != nil {
+		return err
+	}
+	b.Unscaled = intBytes(m.Amount.unscaled())
+	b.Scale = m.Amount.Scale
+	b.Currency = m.Currency
+	return nil
+}
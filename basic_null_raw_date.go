@@ -0,0 +1,35 @@
+package avrox
+
+import (
+	_ "embed"
+
+	"github.com/metatexx/avrox/rawdate"
+)
+
+// Implementation of BasicNullRawDate
+var _ Schemer = (*BasicNullRawDate)(nil)
+
+// BasicNullRawDate is the container type to store a rawdate.NullRawDate (as avro ["null", date] union) in a single avro schema
+type BasicNullRawDate struct {
+	Magic [MagicLen]byte // 1.37.1
+	Value *rawdate.Days
+}
+
+//go:generate avscgen -n "basics" -o avsc/ . BasicNullRawDate
+//go:embed avsc/basic_null_raw_date.avsc
+var BasicNullRawDateAVSC string
+
+// Schema returns the AVRO schema for the BasicNullRawDate struct type
+func (BasicNullRawDate) Schema() string {
+	return BasicNullRawDateAVSC
+}
+
+// NamespaceID returns the namespace id for the BasicNullRawDate struct type
+func (BasicNullRawDate) NamespaceID() NamespaceID {
+	return NamespaceBasic
+}
+
+// SchemaID returns the schema id for the BasicNullRawDate struct type
+func (BasicNullRawDate) SchemaID() SchemaID {
+	return BasicNullRawDateSchemaID
+}
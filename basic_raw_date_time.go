@@ -0,0 +1,58 @@
+package avrox
+
+import (
+	_ "embed"
+	"fmt"
+
+	"github.com/metatexx/avrox/rawdate"
+)
+
+var ErrRawDateTimeInvalid = sentinel("ErrRawDateTimeInvalid", "date and time is invalid")
+
+// Implementation of BasicRawDateTime
+var _ Schemer = (*BasicRawDateTime)(nil)
+
+// BasicRawDateTime is the container type to store a rawdate.RawDateTime (as avro local-timestamp-micros) in a single avro schema
+type BasicRawDateTime struct {
+	Magic [MagicLen]byte // 1.39.1
+	Value rawdate.LocalMicros
+}
+
+//go:generate avscgen -n "basics" -o avsc/ . BasicRawDateTime
+//go:embed avsc/basic_raw_date_time.avsc
+var BasicRawDateTimeAVSC string
+
+// Schema returns the AVRO schema for the BasicRawDateTime struct type
+func (BasicRawDateTime) Schema() string {
+	return BasicRawDateTimeAVSC
+}
+
+// NamespaceID returns the namespace id for the BasicRawDateTime struct type
+func (BasicRawDateTime) NamespaceID() NamespaceID {
+	return NamespaceBasic
+}
+
+// SchemaID returns the schema id for the BasicRawDateTime struct type
+func (BasicRawDateTime) SchemaID() SchemaID {
+	return BasicRawDateTimeSchemaID
+}
+
+// RawDateTime returns the stored value. It fails with ErrRawDateTimeInvalid when the
+// value is outside the dates of rawdate.RawDate.
+func (b BasicRawDateTime) RawDateTime() (rawdate.RawDateTime, error) {
+	dt := b.Value.RawDateTime()
+	if !dt.IsValid() {
+		return rawdate.RawDateTime{}, fmt.Errorf("%w: %d microseconds", ErrRawDateTimeInvalid, b.Value)
+	}
+	return dt, nil
+}
+
+// SetRawDateTime stores the value. It fails with ErrRawDateTimeInvalid when it is no valid
+// date and time.
+func (b *BasicRawDateTime) SetRawDateTime(dt rawdate.RawDateTime) error {
+	if !dt.IsValid() {
+		return fmt.Errorf("%w: %#v", ErrRawDateTimeInvalid, dt)
+	}
+	b.Value = dt.LocalMicros()
+	return nil
+}
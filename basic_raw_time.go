@@ -0,0 +1,57 @@
+package avrox
+
+import (
+	_ "embed"
+	"fmt"
+	"time"
+
+	"github.com/metatexx/avrox/rawdate"
+)
+
+var ErrRawTimeInvalid = sentinel("ErrRawTimeInvalid", "time of day is invalid")
+
+// Implementation of BasicRawTime
+var _ Schemer = (*BasicRawTime)(nil)
+
+// BasicRawTime is the container type to store a rawdate.RawTime (as avro time-micros) in a single avro schema
+type BasicRawTime struct {
+	Magic [MagicLen]byte // 1.38.1
+	Value time.Duration  // time since midnight (stored as microseconds)
+}
+
+//go:generate avscgen -n "basics" -o avsc/ . BasicRawTime
+//go:embed avsc/basic_raw_time.avsc
+var BasicRawTimeAVSC string
+
+// Schema returns the AVRO schema for the BasicRawTime struct type
+func (BasicRawTime) Schema() string {
+	return BasicRawTimeAVSC
+}
+
+// NamespaceID returns the namespace id for the BasicRawTime struct type
+func (BasicRawTime) NamespaceID() NamespaceID {
+	return NamespaceBasic
+}
+
+// SchemaID returns the schema id for the BasicRawTime struct type
+func (BasicRawTime) SchemaID() SchemaID {
+	return BasicRawTimeSchemaID
+}
+
+// RawTime returns the stored value. It fails with ErrRawTimeInvalid when the value is
+// not within 0 and 24 hours (instead of wrapping it around).
+func (b BasicRawTime) RawTime() (rawdate.RawTime, error) {
+	if b.Value < 0 || b.Value >= 24*time.Hour {
+		return rawdate.Midnight, fmt.Errorf("%w: %s after midnight", ErrRawTimeInvalid, b.Value)
+	}
+	return rawdate.TimeFromDuration(b.Value), nil
+}
+
+// SetRawTime stores the value. It fails with ErrRawTimeInvalid when it is no valid time of day.
+func (b *BasicRawTime) SetRawTime(t rawdate.RawTime) error {
+	if !t.IsValid() {
+		return fmt.Errorf("%w: %#v", ErrRawTimeInvalid, t)
+	}
+	b.Value = t.Duration()
+	return nil
+}
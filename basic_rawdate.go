@@ -2,16 +2,20 @@ package avrox
 
 import (
 	_ "embed"
+
 	"github.com/metatexx/avrox/rawdate"
 )
 
-// Implementation of BasicTime
+// Implementation of BasicRawDate
 var _ Schemer = (*BasicRawDate)(nil)
 
-// BasicRawDate is the container type to store a timestamp in a single avro schema
+// BasicRawDate is the container type to store a rawdate.RawDate (as avro date) in a single avro schema.
+// The value is rawdate.Days because hamba/avro can not encode rawdate.RawDate fields as
+// avro date (see rawdate.Days). Days gets encoded natively, so the container also works
+// with avro.Marshal and BasicRawDateAVSC.
 type BasicRawDate struct {
-	Magic [MagicLen]byte // 1.5.1
-	Value rawdate.RawDate
+	Magic [MagicLen]byte // 1.7.2
+	Value rawdate.Days
 }
 
 //go:generate avscgen -n "basics" -o avsc/ . BasicRawDate
@@ -32,3 +36,49 @@ func (BasicRawDate) NamespaceID() NamespaceID {
 func (BasicRawDate) SchemaID() SchemaID {
 	return BasicRawDateSchemaID
 }
+
+// Implementation of BasicRawDateV1
+var _ Schemer = (*BasicRawDateV1)(nil)
+
+// BasicRawDateV1 is the former container type to store a rawdate.RawDate (as record) in a single avro schema.
+// UnmarshalBasic still reads it. Use MigrateBasicRawDate to convert the data.
+type BasicRawDateV1 struct {
+	Magic [MagicLen]byte // 1.7.1
+	Value rawdate.RawDate
+}
+
+//go:generate avscgen -n "basics" -o avsc/ . BasicRawDateV1
+//go:embed avsc/basic_raw_date_v1.avsc
+var BasicRawDateV1AVSC string
+
+// Schema returns the AVRO schema for the BasicRawDateV1 struct type
+func (BasicRawDateV1) Schema() string {
+	return BasicRawDateV1AVSC
+}
+
+// NamespaceID returns the namespace id for the BasicRawDateV1 struct type
+func (BasicRawDateV1) NamespaceID() NamespaceID {
+	return NamespaceBasic
+}
+
+// SchemaID returns the schema id for the BasicRawDateV1 struct type
+func (BasicRawDateV1) SchemaID() SchemaID {
+	return BasicRawDateV1SchemaID
+}
+
+// MigrateBasicRawDate converts BasicRawDateV1 data to BasicRawDate (keeping the compression).
+// Other data is returned unchanged.
+func MigrateBasicRawDate(data []byte) ([]byte, error) {
+	if len(data) < MagicLen {
+		return data, nil
+	}
+	nID, sID, cID, err := DecodeMagic(data[:MagicLen])
+	if err != nil || nID != NamespaceBasic || sID != BasicRawDateV1SchemaID {
+		return data, nil
+	}
+	old := &BasicRawDateV1{}
+	if err = Unmarshal(data, old, basicSchema(BasicRawDateV1SchemaID)); err != nil {
+		return nil, err
+	}
+	return Marshal(&BasicRawDate{Value: old.Value.Days()}, cID, basicSchema(BasicRawDateSchemaID))
+}
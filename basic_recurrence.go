@@ -0,0 +1,51 @@
+package avrox
+
+import (
+	_ "embed"
+
+	"github.com/metatexx/avrox/rawdate"
+)
+
+// Implementation of BasicRecurrence
+var _ Schemer = (*BasicRecurrence)(nil)
+
+// BasicRecurrence is the container type to store a rawdate.Recurrence (start date and RRULE) in a single avro schema
+type BasicRecurrence struct {
+	Magic [MagicLen]byte // 1.36.1
+	Start rawdate.Days
+	Rule  string
+}
+
+//go:generate avscgen -n "basics" -o avsc/ . BasicRecurrence
+//go:embed avsc/basic_recurrence.avsc
+var BasicRecurrenceAVSC string
+
+// Schema returns the AVRO schema for the BasicRecurrence struct type
+func (BasicRecurrence) Schema() string {
+	return BasicRecurrenceAVSC
+}
+
+// NamespaceID returns the namespace id for the BasicRecurrence struct type
+func (BasicRecurrence) NamespaceID() NamespaceID {
+	return NamespaceBasic
+}
+
+// SchemaID returns the schema id for the BasicRecurrence struct type
+func (BasicRecurrence) SchemaID() SchemaID {
+	return BasicRecurrenceSchemaID
+}
+
+// Recurrence parses the stored rule
+func (b BasicRecurrence) Recurrence() (rawdate.Recurrence, error) {
+	return rawdate.ParseRecurrence(b.Start.RawDate(), b.Rule)
+}
+
+// SetRecurrence stores the (validated) rule
+func (b *BasicRecurrence) SetRecurrence(r rawdate.Recurrence) error {
+	if err := r.Validate(); err != nil {
+		return err
+	}
+	b.Start = r.Start.Days()
+	b.Rule = r.String()
+	return nil
+}
@@ -0,0 +1,31 @@
+package avrox
+
+import _ "embed"
+
+// Implementation of BasicStringSlice
+var _ Schemer = (*BasicStringSlice)(nil)
+
+// BasicStringSlice is the container type to store a []string in a single avro schema
+type BasicStringSlice struct {
+	Magic [MagicLen]byte // 1.15.1
+	Value []string
+}
+
+//go:generate avscgen -n "basics" -o avsc/ . BasicStringSlice
+//go:embed avsc/basic_string_slice.avsc
+var BasicStringSliceAVSC string
+
+// Schema returns the AVRO schema for the BasicStringSlice struct type
+func (BasicStringSlice) Schema() string {
+	return BasicStringSliceAVSC
+}
+
+// NamespaceID returns the namespace id for the BasicStringSlice struct type
+func (BasicStringSlice) NamespaceID() NamespaceID {
+	return NamespaceBasic
+}
+
+// SchemaID returns the schema id for the BasicStringSlice struct type
+func (BasicStringSlice) SchemaID() SchemaID {
+	return BasicStringSliceSchemaID
+}
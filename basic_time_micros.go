@@ -0,0 +1,34 @@
+package avrox
+
+import (
+	_ "embed"
+	"time"
+)
+
+// Implementation of BasicTimeMicros
+var _ Schemer = (*BasicTimeMicros)(nil)
+
+// BasicTimeMicros is the container type to store a TimeMicros (timestamp with microseconds) in a single avro schema
+type BasicTimeMicros struct {
+	Magic [MagicLen]byte // 1.25.1
+	Value time.Time
+}
+
+//go:generate avscgen -n "basics" -o avsc/ . BasicTimeMicros
+//go:embed avsc/basic_time_micros.avsc
+var BasicTimeMicrosAVSC string
+
+// Schema returns the AVRO schema for the BasicTimeMicros struct type
+func (BasicTimeMicros) Schema() string {
+	return BasicTimeMicrosAVSC
+}
+
+// NamespaceID returns the namespace id for the BasicTimeMicros struct type
+func (BasicTimeMicros) NamespaceID() NamespaceID {
+	return NamespaceBasic
+}
+
+// SchemaID returns the schema id for the BasicTimeMicros struct type
+func (BasicTimeMicros) SchemaID() SchemaID {
+	return BasicTimeMicrosSchemaID
+}
@@ -0,0 +1,31 @@
+package avrox
+
+import _ "embed"
+
+// Implementation of BasicTimeNanos
+var _ Schemer = (*BasicTimeNanos)(nil)
+
+// BasicTimeNanos is the container type to store a TimeNanos (timestamp with nanoseconds) in a single avro schema
+type BasicTimeNanos struct {
+	Magic [MagicLen]byte // 1.26.1
+	Value int64          // nanoseconds since the unix epoch
+}
+
+//go:generate avscgen -n "basics" -o avsc/ . BasicTimeNanos
+//go:embed avsc/basic_time_nanos.avsc
+var BasicTimeNanosAVSC string
+
+// Schema returns the AVRO schema for the BasicTimeNanos struct type
+func (BasicTimeNanos) Schema() string {
+	return BasicTimeNanosAVSC
+}
+
+// NamespaceID returns the namespace id for the BasicTimeNanos struct type
+func (BasicTimeNanos) NamespaceID() NamespaceID {
+	return NamespaceBasic
+}
+
+// SchemaID returns the schema id for the BasicTimeNanos struct type
+func (BasicTimeNanos) SchemaID() SchemaID {
+	return BasicTimeNanosSchemaID
+}
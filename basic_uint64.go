@@ -0,0 +1,44 @@
+package avrox
+
+import (
+	_ "embed"
+	"encoding/binary"
+)
+
+// Implementation of BasicUint64
+var _ Schemer = (*BasicUint64)(nil)
+
+// BasicUint64 is the container type to store an uint64 (as big endian fixed) in a single avro schema
+type BasicUint64 struct {
+	Magic [MagicLen]byte // 1.10.1
+	Value [8]byte
+}
+
+//go:generate avscgen -n "basics" -o avsc/ . BasicUint64
+//go:embed avsc/basic_uint64.avsc
+var BasicUint64AVSC string
+
+// Schema returns the AVRO schema for the BasicUint64 struct type
+func (BasicUint64) Schema() string {
+	return BasicUint64AVSC
+}
+
+// NamespaceID returns the namespace id for the BasicUint64 struct type
+func (BasicUint64) NamespaceID() NamespaceID {
+	return NamespaceBasic
+}
+
+// SchemaID returns the schema id for the BasicUint64 struct type
+func (BasicUint64) SchemaID() SchemaID {
+	return BasicUint64SchemaID
+}
+
+// Uint64 returns the stored value
+func (b BasicUint64) Uint64() uint64 {
+	return binary.BigEndian.Uint64(b.Value[:])
+}
+
+// SetUint64 stores the value
+func (b *BasicUint64) SetUint64(v uint64) {
+	binary.BigEndian.PutUint64(b.Value[:], v)
+}
@@ -0,0 +1,31 @@
+package avrox
+
+import _ "embed"
+
+// Implementation of BasicURL
+var _ Schemer = (*BasicURL)(nil)
+
+// BasicURL is the container type to store a *url.URL in a single avro schema
+type BasicURL struct {
+	Magic [MagicLen]byte // 1.31.1
+	Value string
+}
+
+//go:generate avscgen -n "basics" -o avsc/ . BasicURL
+//go:embed avsc/basic_url.avsc
+var BasicURLAVSC string
+
+// Schema returns the AVRO schema for the BasicURL struct type
+func (BasicURL) Schema() string {
+	return BasicURLAVSC
+}
+
+// NamespaceID returns the namespace id for the BasicURL struct type
+func (BasicURL) NamespaceID() NamespaceID {
+	return NamespaceBasic
+}
+
+// SchemaID returns the schema id for the BasicURL struct type
+func (BasicURL) SchemaID() SchemaID {
+	return BasicURLSchemaID
+}
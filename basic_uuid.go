@@ -0,0 +1,31 @@
+package avrox
+
+import _ "embed"
+
+// Implementation of BasicUUID
+var _ Schemer = (*BasicUUID)(nil)
+
+// BasicUUID is the container type to store an UUID (as avro uuid logical type) in a single avro schema
+type BasicUUID struct {
+	Magic [MagicLen]byte // 1.14.1
+	Value UUID
+}
+
+//go:generate avscgen -n "basics" -o avsc/ . BasicUUID
+//go:embed avsc/basic_uuid.avsc
+var BasicUUIDAVSC string
+
+// Schema returns the AVRO schema for the BasicUUID struct type
+func (BasicUUID) Schema() string {
+	return BasicUUIDAVSC
+}
+
+// NamespaceID returns the namespace id for the BasicUUID struct type
+func (BasicUUID) NamespaceID() NamespaceID {
+	return NamespaceBasic
+}
+
+// SchemaID returns the schema id for the BasicUUID struct type
+func (BasicUUID) SchemaID() SchemaID {
+	return BasicUUIDSchemaID
+}
@@ -0,0 +1,33 @@
+package avrox
+
+import _ "embed"
+
+// Implementation of BasicZonedTime
+var _ Schemer = (*BasicZonedTime)(nil)
+
+// BasicZonedTime is the container type to store a ZonedTime (instant and location) in a single avro schema
+type BasicZonedTime struct {
+	Magic    [MagicLen]byte // 1.27.1
+	Value    int64          // nanoseconds since the unix epoch
+	Location string         // IANA name of the location (e.g. "Europe/Berlin")
+	Offset   int            // zone offset in seconds (used when the location is unknown)
+}
+
+//go:generate avscgen -n "basics" -o avsc/ . BasicZonedTime
+//go:embed avsc/basic_zoned_time.avsc
+var BasicZonedTimeAVSC string
+
+// Schema returns the AVRO schema for the BasicZonedTime struct type
+func (BasicZonedTime) Schema() string {
+	return BasicZonedTimeAVSC
+}
+
+// NamespaceID returns the namespace id for the BasicZonedTime struct type
+func (BasicZonedTime) NamespaceID() NamespaceID {
+	return NamespaceBasic
+}
+
+// SchemaID returns the schema id for the BasicZonedTime struct type
+func (BasicZonedTime) SchemaID() SchemaID {
+	return BasicZonedTimeSchemaID
+}
@@ -1,13 +1,229 @@
 package avrox
 
 import (
-	"errors"
-	"github.com/hamba/avro/v2"
-	"github.com/metatexx/avrox/rawdate"
 	"math/big"
+	"net"
+	"net/netip"
+	"net/url"
+	"reflect"
 	"time"
+
+	"github.com/hamba/avro/v2"
+	"github.com/metatexx/avrox/rawdate"
+)
+
+// basicType describes a basic kind. It gets registered once with registerBasic.
+type basicType struct {
+	goType        reflect.Type
+	containerType reflect.Type
+	schemaID      SchemaID
+	schema        avro.Schema
+	errNoBasic    error
+	marshal       func(v any, cID CompressionID) ([]byte, error)
+	unmarshal     func(data []byte) (any, error)
+}
+
+var (
+	basicBySchemaID = map[SchemaID]*basicType{}
+	basicByGoType   = map[reflect.Type]*basicType{}
 )
 
+// registerBasic registers the container K (with its schema and schema id) for the go type T.
+// The get and set functions convert between the container and the value.
+func registerBasic[T any, K any, PK interface {
+	*K
+	Schemer
+}](errNoBasic error, get func(*K) T, set func(*K, T)) {
+	registerBasicE[T, K, PK](errNoBasic,
+		func(k *K) (T, error) { return get(k), nil },
+		func(k *K, v T) error {
+			set(k, v)
+			return nil
+		})
+}
+
+// registerBasicE is registerBasic for values where the conversion can fail
+func registerBasicE[T any, K any, PK interface {
+	*K
+	Schemer
+}](errNoBasic error, get func(*K) (T, error), set func(*K, T) error) {
+	var container K
+	bt := &basicType{
+		goType:        reflect.TypeOf((*T)(nil)).Elem(),
+		containerType: reflect.TypeOf(container),
+		schemaID:      PK(&container).SchemaID(),
+		schema:        avro.MustParse(PK(&container).Schema()),
+		errNoBasic:    errNoBasic,
+	}
+	bt.marshal = func(v any, cID CompressionID) ([]byte, error) {
+		kind := new(K)
+		if err := set(kind, v.(T)); err != nil {
+			return nil, err
+		}
+		return Marshal(PK(kind), cID, bt.schema)
+	}
+	bt.unmarshal = func(data []byte) (any, error) {
+		kind := new(K)
+		if err := Unmarshal(data, PK(kind), bt.schema); err != nil {
+			return nil, err
+		}
+		return get(kind)
+	}
+	if _, found := basicBySchemaID[bt.schemaID]; found {
+		panic("basic schema registered twice: " + NSV(NamespaceBasic, bt.schemaID))
+	}
+	basicBySchemaID[bt.schemaID] = bt
+	basicByGoType[bt.goType] = bt
+}
+
+func init() {
+	registerBasic[string, BasicString](ErrNoBasicString,
+		func(k *BasicString) string { return k.Value },
+		func(k *BasicString, v string) { k.Value = v })
+	registerBasic[int, BasicInt](ErrNoBasicInt,
+		func(k *BasicInt) int { return k.Value },
+		func(k *BasicInt, v int) { k.Value = v })
+	registerBasic[[]byte, BasicByteSlice](ErrNoBasicByteSlice,
+		func(k *BasicByteSlice) []byte { return k.Value },
+		func(k *BasicByteSlice, v []byte) { k.Value = v })
+	// the former flat union encoding can still be unmarshalled
+	// (registering BasicMapStringAny afterward makes it the one used for marshalling)
+	registerBasic[map[string]any, BasicMapStringAnyV1](ErrNoBasicMapStringAny,
+		func(k *BasicMapStringAnyV1) map[string]any { return k.Value },
+		func(k *BasicMapStringAnyV1, v map[string]any) { k.Value = v })
+	registerBasic[map[string]any, BasicMapStringAny](ErrNoBasicMapStringAny,
+		func(k *BasicMapStringAny) map[string]any { return k.Value },
+		func(k *BasicMapStringAny, v map[string]any) { k.Value = v })
+	registerBasic[time.Time, BasicTime](ErrNoBasicTime,
+		func(k *BasicTime) time.Time { return k.Value },
+		func(k *BasicTime, v time.Time) { k.Value = v })
+	registerBasic[*big.Rat, BasicDecimal](ErrNoBasicDecimal,
+		func(k *BasicDecimal) *big.Rat { return k.Value },
+		func(k *BasicDecimal, v *big.Rat) { k.Value = v })
+	// the former record encoding of rawdate.RawDate can still be unmarshalled
+	// (registering BasicRawDate afterward makes it the one used for marshalling)
+	registerBasic[rawdate.RawDate, BasicRawDateV1](ErrNoBasicRawDate,
+		func(k *BasicRawDateV1) rawdate.RawDate { return k.Value },
+		func(k *BasicRawDateV1, v rawdate.RawDate) { k.Value = v })
+	registerBasic[rawdate.RawDate, BasicRawDate](ErrNoBasicRawDate,
+		func(k *BasicRawDate) rawdate.RawDate { return k.Value.RawDate() },
+		func(k *BasicRawDate, v rawdate.RawDate) { k.Value = v.Days() })
+	registerBasic[bool, BasicBool](ErrNoBasicBool,
+		func(k *BasicBool) bool { return k.Value },
+		func(k *BasicBool, v bool) { k.Value = v })
+	registerBasic[int64, BasicInt64](ErrNoBasicInt64,
+		func(k *BasicInt64) int64 { return k.Value },
+		func(k *BasicInt64, v int64) { k.Value = v })
+	registerBasic[uint64, BasicUint64](ErrNoBasicUint64,
+		func(k *BasicUint64) uint64 { return k.Uint64() },
+		func(k *BasicUint64, v uint64) { k.SetUint64(v) })
+	registerBasic[float32, BasicFloat32](ErrNoBasicFloat32,
+		func(k *BasicFloat32) float32 { return k.Value },
+		func(k *BasicFloat32, v float32) { k.Value = v })
+	registerBasic[float64, BasicFloat64](ErrNoBasicFloat64,
+		func(k *BasicFloat64) float64 { return k.Value },
+		func(k *BasicFloat64, v float64) { k.Value = v })
+	registerBasic[time.Duration, BasicDuration](ErrNoBasicDuration,
+		func(k *BasicDuration) time.Duration { return k.Value },
+		func(k *BasicDuration, v time.Duration) { k.Value = v })
+	registerBasic[UUID, BasicUUID](ErrNoBasicUUID,
+		func(k *BasicUUID) UUID { return k.Value },
+		func(k *BasicUUID, v UUID) { k.Value = v })
+	registerBasic[[]string, BasicStringSlice](ErrNoBasicStringSlice,
+		func(k *BasicStringSlice) []string { return k.Value },
+		func(k *BasicStringSlice, v []string) { k.Value = v })
+	registerBasic[[]int, BasicIntSlice](ErrNoBasicIntSlice,
+		func(k *BasicIntSlice) []int { return k.Value },
+		func(k *BasicIntSlice, v []int) { k.Value = v })
+	registerBasic[[]int64, BasicInt64Slice](ErrNoBasicInt64Slice,
+		func(k *BasicInt64Slice) []int64 { return k.Value },
+		func(k *BasicInt64Slice, v []int64) { k.Value = v })
+	registerBasic[[]float64, BasicFloat64Slice](ErrNoBasicFloat64Slice,
+		func(k *BasicFloat64Slice) []float64 { return k.Value },
+		func(k *BasicFloat64Slice, v []float64) { k.Value = v })
+	registerBasic[map[string]string, BasicMapStringString](ErrNoBasicMapStringString,
+		func(k *BasicMapStringString) map[string]string { return k.Value },
+		func(k *BasicMapStringString, v map[string]string) { k.Value = v })
+	registerBasic[map[string]int, BasicMapStringInt](ErrNoBasicMapStringInt,
+		func(k *BasicMapStringInt) map[string]int { return k.Value },
+		func(k *BasicMapStringInt, v map[string]int) { k.Value = v })
+	registerBasic[List, BasicList](ErrNoBasicList,
+		func(k *BasicList) List { return k.Value },
+		func(k *BasicList, v List) { k.Value = v })
+	registerBasic[*RemoteError, BasicError](ErrNoBasicError,
+		func(k *BasicError) *RemoteError { return k.RemoteError() },
+		func(k *BasicError, v *RemoteError) {
+			if v != nil {
+				*k = *NewBasicError(v)
+			}
+		})
+	registerBasicE[Decimal, BasicDecimalScaled](ErrNoBasicDecimalScaled,
+		func(k *BasicDecimalScaled) (Decimal, error) { return k.Decimal() },
+		func(k *BasicDecimalScaled, v Decimal) error { return k.SetDecimal(v) })
+	registerBasicE[Money, BasicMoney](ErrNoBasicMoney,
+		func(k *BasicMoney) (Money, error) { return k.Money() },
+		func(k *BasicMoney, v Money) error { return k.SetMoney(v) })
+	registerBasic[TimeMicros, BasicTimeMicros](ErrNoBasicTimeMicros,
+		func(k *BasicTimeMicros) TimeMicros { return k.TimeMicros() },
+		func(k *BasicTimeMicros, v TimeMicros) { k.SetTimeMicros(v) })
+	registerBasicE[TimeNanos, BasicTimeNanos](ErrNoBasicTimeNanos,
+		func(k *BasicTimeNanos) (TimeNanos, error) { return k.TimeNanos(), nil },
+		func(k *BasicTimeNanos, v TimeNanos) error { return k.SetTimeNanos(v) })
+	registerBasicE[ZonedTime, BasicZonedTime](ErrNoBasicZonedTime,
+		func(k *BasicZonedTime) (ZonedTime, error) { return k.ZonedTime(), nil },
+		func(k *BasicZonedTime, v ZonedTime) error { return k.SetZonedTime(v) })
+	registerBasicE[LocalTimestamp, BasicLocalTimestamp](ErrNoBasicLocalTimestamp,
+		func(k *BasicLocalTimestamp) (LocalTimestamp, error) { return k.LocalTimestamp(), nil },
+		func(k *BasicLocalTimestamp, v LocalTimestamp) error { return k.SetLocalTimestamp(v) })
+	registerBasic[netip.Addr, BasicIPAddr](ErrNoBasicIPAddr,
+		func(k *BasicIPAddr) netip.Addr { return k.Value.Addr() },
+		func(k *BasicIPAddr, v netip.Addr) { k.Value = NewIPAddr(v) })
+	registerBasic[netip.Prefix, BasicIPPrefix](ErrNoBasicIPPrefix,
+		func(k *BasicIPPrefix) netip.Prefix { return k.Value.Prefix() },
+		func(k *BasicIPPrefix, v netip.Prefix) { k.Value = NewIPPrefix(v) })
+	registerBasicE[*url.URL, BasicURL](ErrNoBasicURL,
+		func(k *BasicURL) (*url.URL, error) { return url.Parse(k.Value) },
+		func(k *BasicURL, v *url.URL) error {
+			if v == nil {
+				return ErrNoData
+			}
+			k.Value = v.String()
+			return nil
+		})
+	registerBasic[net.HardwareAddr, BasicMAC](ErrNoBasicMAC,
+		func(k *BasicMAC) net.HardwareAddr { return net.HardwareAddr(k.Value) },
+		func(k *BasicMAC, v net.HardwareAddr) { k.Value = v })
+	registerBasic[Envelope, BasicEnvelope](ErrNoBasicEnvelope,
+		func(k *BasicEnvelope) Envelope { return k.Envelope() },
+		func(k *BasicEnvelope, v Envelope) { k.SetEnvelope(v) })
+	registerBasic[rawdate.Range, BasicDateRange](ErrNoBasicDateRange,
+		func(k *BasicDateRange) rawdate.Range { return k.Value.Range() },
+		func(k *BasicDateRange, v rawdate.Range) { k.Value = NewDateRange(v) })
+	registerBasic[rawdate.RangeSet, BasicDateRangeSet](ErrNoBasicDateRangeSet,
+		func(k *BasicDateRangeSet) rawdate.RangeSet { return DateRangeSet(k.Value) },
+		func(k *BasicDateRangeSet, v rawdate.RangeSet) { k.Value = NewDateRanges(v) })
+	registerBasicE[rawdate.Recurrence, BasicRecurrence](ErrNoBasicRecurrence,
+		func(k *BasicRecurrence) (rawdate.Recurrence, error) { return k.Recurrence() },
+		func(k *BasicRecurrence, v rawdate.Recurrence) error { return k.SetRecurrence(v) })
+	registerBasic[rawdate.NullRawDate, BasicNullRawDate](ErrNoBasicNullRawDate,
+		func(k *BasicNullRawDate) rawdate.NullRawDate { return rawdate.NullRawDateFromDays(k.Value) },
+		func(k *BasicNullRawDate, v rawdate.NullRawDate) { k.Value = v.Days() })
+	registerBasicE[rawdate.RawTime, BasicRawTime](ErrNoBasicRawTime,
+		func(k *BasicRawTime) (rawdate.RawTime, error) { return k.RawTime() },
+		func(k *BasicRawTime, v rawdate.RawTime) error { return k.SetRawTime(v) })
+	registerBasicE[rawdate.RawDateTime, BasicRawDateTime](ErrNoBasicRawDateTime,
+		func(k *BasicRawDateTime) (rawdate.RawDateTime, error) { return k.RawDateTime() },
+		func(k *BasicRawDateTime, v rawdate.RawDateTime) error { return k.SetRawDateTime(v) })
+}
+
+// basicSchema returns the parsed schema of a basic type (nil if it is unknown)
+func basicSchema(sID SchemaID) avro.Schema {
+	if bt, found := basicBySchemaID[sID]; found {
+		return bt.schema
+	}
+	return nil
+}
+
 func MustEncodeBasicMagic(schemaID SchemaID, compression CompressionID) Magic {
 	m, err := EncodeMagic(NamespaceBasic, schemaID, compression)
 	if err != nil {
@@ -16,97 +232,29 @@ func MustEncodeBasicMagic(schemaID SchemaID, compression CompressionID) Magic {
 	return m
 }
 
+// MarshalBasic marshals a value of one of the basic types (or a pointer to it)
 func MarshalBasic(src any, cID CompressionID) ([]byte, error) {
-	var data []byte
-	var errMarshall error
-	switch v := src.(type) {
-	case string:
-		kind := &BasicString{
-			Magic: MustEncodeBasicMagic(BasicStringSchemaID, cID),
-			Value: v,
-		}
-		data, errMarshall = avro.Marshal(avro.MustParse(BasicStringAVSC), kind)
-	case *string:
-		kind := &BasicString{
-			Magic: MustEncodeBasicMagic(BasicStringSchemaID, cID),
-			Value: *v,
-		}
-		data, errMarshall = avro.Marshal(avro.MustParse(BasicStringAVSC), kind)
-	case int:
-		kind := &BasicInt{
-			Magic: MustEncodeBasicMagic(BasicIntSchemaID, cID),
-			Value: v,
-		}
-		data, errMarshall = avro.Marshal(avro.MustParse(BasicIntAVSC), kind)
-	case *int:
-		kind := &BasicInt{
-			Magic: MustEncodeBasicMagic(BasicIntSchemaID, cID),
-			Value: *v,
-		}
-		data, errMarshall = avro.Marshal(avro.MustParse(BasicIntAVSC), kind)
-	case []byte:
-		kind := &BasicByteSlice{
-			Magic: MustEncodeBasicMagic(BasicByteSliceSchemaID, cID),
-			Value: v,
-		}
-		data, errMarshall = avro.Marshal(avro.MustParse(BasicByteSliceAVSC), kind)
-	case *[]byte:
-		kind := &BasicByteSlice{
-			Magic: MustEncodeBasicMagic(BasicByteSliceSchemaID, cID),
-			Value: *v,
-		}
-		data, errMarshall = avro.Marshal(avro.MustParse(BasicByteSliceAVSC), kind)
-	case map[string]any:
-		kind := &BasicMapStringAny{
-			Magic: MustEncodeBasicMagic(BasicMapStringAnySchemaID, cID),
-			Value: v,
-		}
-		data, errMarshall = avro.Marshal(avro.MustParse(BasicMapStringAnyAVSC), kind)
-	case *map[string]any:
-		kind := &BasicMapStringAny{
-			Magic: MustEncodeBasicMagic(BasicMapStringAnySchemaID, cID),
-			Value: *v,
-		}
-		data, errMarshall = avro.Marshal(avro.MustParse(BasicMapStringAnyAVSC), kind)
-	case time.Time:
-		kind := &BasicTime{
-			Magic: MustEncodeBasicMagic(BasicTimeSchemaID, cID),
-			Value: v,
-		}
-		data, errMarshall = avro.Marshal(avro.MustParse(BasicTimeAVSC), kind)
-	case *time.Time:
-		kind := &BasicTime{
-			Magic: MustEncodeBasicMagic(BasicTimeSchemaID, cID),
-			Value: *v,
-		}
-		data, errMarshall = avro.Marshal(avro.MustParse(BasicTimeAVSC), kind)
-	case *big.Rat:
-		kind := &BasicDecimal{
-			Magic: MustEncodeBasicMagic(BasicDecimalSchemaID, cID),
-			Value: v,
-		}
-		data, errMarshall = avro.Marshal(avro.MustParse(BasicDecimalAVSC), kind)
-	case rawdate.RawDate:
-		kind := &BasicRawDate{
-			Magic: MustEncodeBasicMagic(BasicRawDateSchemaID, cID),
-			Value: v,
-		}
-		data, errMarshall = avro.Marshal(avro.MustParse(BasicRawDateAVSC), kind)
-	case *rawdate.RawDate:
-		kind := &BasicRawDate{
-			Magic: MustEncodeBasicMagic(BasicRawDateSchemaID, cID),
-			Value: *v,
+	if bt, found := basicByGoType[reflect.TypeOf(src)]; found {
+		return bt.marshal(src, cID)
+	}
+	v := reflect.ValueOf(src)
+	if v.Kind() == reflect.Ptr && !v.IsNil() {
+		if bt, found := basicByGoType[v.Type().Elem()]; found {
+			return bt.marshal(v.Elem().Interface(), cID)
 		}
-		data, errMarshall = avro.Marshal(avro.MustParse(BasicRawDateAVSC), kind)
-	default:
-		return nil, errors.New("unsupported type")
 	}
-	if errMarshall != nil {
-		return nil, errMarshall
+	return nil, ErrBasicTypeNotSupported
+}
+
+// MarshalBasicValue marshals a value of one of the basic types
+func MarshalBasicValue[T any](src T, cID CompressionID) ([]byte, error) {
+	if bt, found := basicByGoType[reflect.TypeOf((*T)(nil)).Elem()]; found {
+		return bt.marshal(src, cID)
 	}
-	return CompressData(data, cID)
+	return MarshalBasic(src, cID)
 }
 
+// UnmarshalBasic unmarshals any of the basic types and returns its value
 func UnmarshalBasic(src []byte) (any, error) {
 	if len(src) == 0 {
 		return nil, nil
@@ -121,104 +269,166 @@ func UnmarshalBasic(src []byte) (any, error) {
 	if nID != NamespaceBasic {
 		return nil, ErrNoBasicNamespace
 	}
-
-	var errUnmarshalAny error
-
-	//nolint:exhaustive // only runs for NamespaceBasic
-	switch sID {
-	case BasicStringSchemaID:
-		kind := &BasicString{}
-		nID, sID, errUnmarshalAny = UnmarshalAny(src, avro.MustParse(BasicStringAVSC), kind)
-		if errUnmarshalAny != nil {
-			return nil, errUnmarshalAny
-		}
-		if nID != NamespaceBasic && sID != BasicStringSchemaID {
-			return nil, ErrNoBasicString
-		}
-		return kind.Value, nil
-	case BasicIntSchemaID:
-		kind := &BasicInt{}
-		nID, sID, errUnmarshalAny = UnmarshalAny(src, avro.MustParse(BasicIntAVSC), kind)
-		if errUnmarshalAny != nil {
-			return nil, errUnmarshalAny
-		}
-		if nID != NamespaceBasic && sID != BasicIntSchemaID {
-			return nil, ErrNoBasicInt
-		}
-		return kind.Value, nil
-	case BasicByteSliceSchemaID:
-		kind := &BasicByteSlice{}
-		nID, sID, errUnmarshalAny = UnmarshalAny(src, avro.MustParse(BasicByteSliceAVSC), kind)
-		if errUnmarshalAny != nil {
-			return nil, errUnmarshalAny
-		}
-		if nID != NamespaceBasic && sID != BasicByteSliceSchemaID {
-			return nil, ErrNoBasicByteSlice
-		}
-		return kind.Value, nil
-	case BasicMapStringAnySchemaID:
-		kind := &BasicMapStringAny{}
-		nID, sID, errUnmarshalAny = UnmarshalAny(src, avro.MustParse(BasicMapStringAnyAVSC), kind)
-		if errUnmarshalAny != nil {
-			return nil, errUnmarshalAny
-		}
-		if nID != NamespaceBasic && sID != BasicByteSliceSchemaID {
-			return nil, ErrNoBasicMapStringAny
-		}
-		return kind.Value, nil
-	case BasicTimeSchemaID:
-		kind := &BasicTime{}
-		nID, sID, errUnmarshalAny = UnmarshalAny(src, avro.MustParse(BasicTimeAVSC), kind)
-		if errUnmarshalAny != nil {
-			return nil, errUnmarshalAny
-		}
-		if nID != NamespaceBasic && sID != BasicTimeSchemaID {
-			return nil, ErrNoBasicTime
-		}
-		return kind.Value, nil
-	case BasicRawDateSchemaID:
-		kind := &BasicRawDate{}
-		nID, sID, errUnmarshalAny = UnmarshalAny(src, avro.MustParse(BasicRawDateAVSC), kind)
-		if errUnmarshalAny != nil {
-			return nil, errUnmarshalAny
-		}
-		if nID != NamespaceBasic && sID != BasicRawDateSchemaID {
-			return nil, ErrNoBasicRawDate
-		}
-		return kind.Value, nil
-	case BasicDecimalSchemaID:
-		kind := &BasicDecimal{}
-		nID, sID, errUnmarshalAny = UnmarshalAny(src, avro.MustParse(BasicDecimalAVSC), kind)
-		if errUnmarshalAny != nil {
-			return nil, errUnmarshalAny
-		}
-		if nID != NamespaceBasic && sID != BasicDecimalSchemaID {
-			return nil, ErrNoBasicDecimal
-		}
-		return kind.Value, nil
-	default:
+	bt, found := basicBySchemaID[sID]
+	if !found {
 		return nil, ErrNoBasicSchema
 	}
+	return bt.unmarshal(src)
 }
 
-func UnmarshalString(data []byte) (string, error) {
-	x, err := UnmarshalBasic(data)
+// UnmarshalBasicAs unmarshals a basic type and returns its value as T.
+// It returns the ErrNoBasic... error for T when the data contains another basic type.
+func UnmarshalBasicAs[T any](src []byte) (T, error) {
+	var zero T
+	x, err := UnmarshalBasic(src)
 	if err != nil {
-		return "", err
+		return zero, err
 	}
-	if str, ok := x.(string); ok {
-		return str, nil
+	if v, ok := x.(T); ok {
+		return v, nil
 	}
-	return "", ErrNoBasicString
+	if bt, found := basicByGoType[reflect.TypeOf((*T)(nil)).Elem()]; found {
+		return zero, bt.errNoBasic
+	}
+	return zero, ErrBasicTypeNotSupported
+}
+
+func UnmarshalString(data []byte) (string, error) {
+	return UnmarshalBasicAs[string](data)
 }
 
 func UnmarshalInt(data []byte) (int, error) {
-	x, err := UnmarshalBasic(data)
-	if err != nil {
-		return 0, err
-	}
-	if n, ok := x.(int); ok {
-		return n, nil
+	return UnmarshalBasicAs[int](data)
+}
+
+func UnmarshalBool(data []byte) (bool, error) {
+	return UnmarshalBasicAs[bool](data)
+}
+
+func UnmarshalInt64(data []byte) (int64, error) {
+	return UnmarshalBasicAs[int64](data)
+}
+
+func UnmarshalUint64(data []byte) (uint64, error) {
+	return UnmarshalBasicAs[uint64](data)
+}
+
+func UnmarshalFloat32(data []byte) (float32, error) {
+	return UnmarshalBasicAs[float32](data)
+}
+
+func UnmarshalFloat64(data []byte) (float64, error) {
+	return UnmarshalBasicAs[float64](data)
+}
+
+func UnmarshalDuration(data []byte) (time.Duration, error) {
+	return UnmarshalBasicAs[time.Duration](data)
+}
+
+func UnmarshalUUID(data []byte) (UUID, error) {
+	return UnmarshalBasicAs[UUID](data)
+}
+
+func UnmarshalStringSlice(data []byte) ([]string, error) {
+	return UnmarshalBasicAs[[]string](data)
+}
+
+func UnmarshalIntSlice(data []byte) ([]int, error) {
+	return UnmarshalBasicAs[[]int](data)
+}
+
+func UnmarshalInt64Slice(data []byte) ([]int64, error) {
+	return UnmarshalBasicAs[[]int64](data)
+}
+
+func UnmarshalFloat64Slice(data []byte) ([]float64, error) {
+	return UnmarshalBasicAs[[]float64](data)
+}
+
+func UnmarshalMapStringString(data []byte) (map[string]string, error) {
+	return UnmarshalBasicAs[map[string]string](data)
+}
+
+func UnmarshalMapStringInt(data []byte) (map[string]int, error) {
+	return UnmarshalBasicAs[map[string]int](data)
+}
+
+func UnmarshalList(data []byte) (List, error) {
+	return UnmarshalBasicAs[List](data)
+}
+
+func UnmarshalDecimal(data []byte) (Decimal, error) {
+	return UnmarshalBasicAs[Decimal](data)
+}
+
+func UnmarshalMoney(data []byte) (Money, error) {
+	return UnmarshalBasicAs[Money](data)
+}
+
+func UnmarshalTimeMicros(data []byte) (TimeMicros, error) {
+	return UnmarshalBasicAs[TimeMicros](data)
+}
+
+func UnmarshalTimeNanos(data []byte) (TimeNanos, error) {
+	return UnmarshalBasicAs[TimeNanos](data)
+}
+
+func UnmarshalZonedTime(data []byte) (ZonedTime, error) {
+	return UnmarshalBasicAs[ZonedTime](data)
+}
+
+func UnmarshalLocalTimestamp(data []byte) (LocalTimestamp, error) {
+	return UnmarshalBasicAs[LocalTimestamp](data)
+}
+
+func UnmarshalIPAddr(data []byte) (netip.Addr, error) {
+	return UnmarshalBasicAs[netip.Addr](data)
+}
+
+func UnmarshalIPPrefix(data []byte) (netip.Prefix, error) {
+	return UnmarshalBasicAs[netip.Prefix](data)
+}
+
+func UnmarshalURL(data []byte) (*url.URL, error) {
+	return UnmarshalBasicAs[*url.URL](data)
+}
+
+func UnmarshalMAC(data []byte) (net.HardwareAddr, error) {
+	return UnmarshalBasicAs[net.HardwareAddr](data)
+}
+
+func UnmarshalEnvelope(data []byte) (Envelope, error) {
+	return UnmarshalBasicAs[Envelope](data)
+}
+
+func UnmarshalDateRange(data []byte) (rawdate.Range, error) {
+	return UnmarshalBasicAs[rawdate.Range](data)
+}
+
+func UnmarshalDateRangeSet(data []byte) (rawdate.RangeSet, error) {
+	return UnmarshalBasicAs[rawdate.RangeSet](data)
+}
+
+func UnmarshalRecurrence(data []byte) (rawdate.Recurrence, error) {
+	return UnmarshalBasicAs[rawdate.Recurrence](data)
+}
+
+func UnmarshalNullRawDate(data []byte) (rawdate.NullRawDate, error) {
+	return UnmarshalBasicAs[rawdate.NullRawDate](data)
+}
+
+func UnmarshalRawTime(data []byte) (rawdate.RawTime, error) {
+	return UnmarshalBasicAs[rawdate.RawTime](data)
+}
+
+func UnmarshalRawDateTime(data []byte) (rawdate.RawDateTime, error) {
+	return UnmarshalBasicAs[rawdate.RawDateTime](data)
+}
+
+// UnmarshalError returns the transported error (see MarshalError). No data results in a nil error.
+func UnmarshalError(data []byte) (*RemoteError, error) {
+	if len(data) == 0 {
+		return nil, nil
 	}
-	return 0, ErrNoBasicString
+	return UnmarshalBasicAs[*RemoteError](data)
 }
@@ -14,8 +14,11 @@ const (
 	// BasicByteSliceSchemaID is the id for the avro schema of struct BasicInt
 	BasicByteSliceSchemaID SchemaID = 3<<8 + 1
 
-	// BasicMapStringAnySchemaID is the id for the avro schema of struct BasicMapStringAny
-	BasicMapStringAnySchemaID SchemaID = 4<<8 + 1
+	// BasicMapStringAnySchemaID is the id for the avro schema of struct BasicMapStringAny (recursive AnyValue)
+	BasicMapStringAnySchemaID SchemaID = 4<<8 + 2
+
+	// BasicMapStringAnyV1SchemaID is the id for the former avro schema of struct BasicMapStringAnyV1 (flat union values)
+	BasicMapStringAnyV1SchemaID SchemaID = 4<<8 + 1
 
 	// BasicTimeSchemaID is the id for the avro schema of struct BasicTime
 	BasicTimeSchemaID SchemaID = 5<<8 + 1
@@ -23,6 +26,108 @@ const (
 	// BasicDecimalSchemaID is the id for the avro schema of struct BasicDecimal (*big.Rat / decimal.fixed)
 	BasicDecimalSchemaID SchemaID = 6<<8 + 1
 
-	// BasicRawDateSchemaID is the id for the avro schema of struct BasicRawDate (rawdate.Rawdate)
-	BasicRawDateSchemaID SchemaID = 7<<8 + 1
+	// BasicRawDateSchemaID is the id for the avro schema of struct BasicRawDate (rawdate.Rawdate as avro date)
+	BasicRawDateSchemaID SchemaID = 7<<8 + 2
+
+	// BasicRawDateV1SchemaID is the id for the former avro schema of struct BasicRawDateV1 (rawdate.Rawdate as record)
+	BasicRawDateV1SchemaID SchemaID = 7<<8 + 1
+
+	// BasicBoolSchemaID is the id for the avro schema of struct BasicBool
+	BasicBoolSchemaID SchemaID = 8<<8 + 1
+
+	// BasicInt64SchemaID is the id for the avro schema of struct BasicInt64
+	BasicInt64SchemaID SchemaID = 9<<8 + 1
+
+	// BasicUint64SchemaID is the id for the avro schema of struct BasicUint64 (big endian fixed)
+	BasicUint64SchemaID SchemaID = 10<<8 + 1
+
+	// BasicFloat32SchemaID is the id for the avro schema of struct BasicFloat32
+	BasicFloat32SchemaID SchemaID = 11<<8 + 1
+
+	// BasicFloat64SchemaID is the id for the avro schema of struct BasicFloat64
+	BasicFloat64SchemaID SchemaID = 12<<8 + 1
+
+	// BasicDurationSchemaID is the id for the avro schema of struct BasicDuration (time.Duration)
+	BasicDurationSchemaID SchemaID = 13<<8 + 1
+
+	// BasicUUIDSchemaID is the id for the avro schema of struct BasicUUID (avrox.UUID / uuid)
+	BasicUUIDSchemaID SchemaID = 14<<8 + 1
+
+	// BasicStringSliceSchemaID is the id for the avro schema of struct BasicStringSlice
+	BasicStringSliceSchemaID SchemaID = 15<<8 + 1
+
+	// BasicIntSliceSchemaID is the id for the avro schema of struct BasicIntSlice
+	BasicIntSliceSchemaID SchemaID = 16<<8 + 1
+
+	// BasicInt64SliceSchemaID is the id for the avro schema of struct BasicInt64Slice
+	BasicInt64SliceSchemaID SchemaID = 17<<8 + 1
+
+	// BasicFloat64SliceSchemaID is the id for the avro schema of struct BasicFloat64Slice
+	BasicFloat64SliceSchemaID SchemaID = 18<<8 + 1
+
+	// BasicMapStringStringSchemaID is the id for the avro schema of struct BasicMapStringString
+	BasicMapStringStringSchemaID SchemaID = 19<<8 + 1
+
+	// BasicMapStringIntSchemaID is the id for the avro schema of struct BasicMapStringInt
+	BasicMapStringIntSchemaID SchemaID = 20<<8 + 1
+
+	// BasicListSchemaID is the id for the avro schema of struct BasicList (nested avrox messages)
+	BasicListSchemaID SchemaID = 21<<8 + 1
+
+	// BasicErrorSchemaID is the id for the avro schema of struct BasicError (see RemoteError)
+	BasicErrorSchemaID SchemaID = 22<<8 + 1
+
+	// BasicDecimalScaledSchemaID is the id for the avro schema of struct BasicDecimalScaled (avrox.Decimal)
+	BasicDecimalScaledSchemaID SchemaID = 23<<8 + 1
+
+	// BasicMoneySchemaID is the id for the avro schema of struct BasicMoney (avrox.Money)
+	BasicMoneySchemaID SchemaID = 24<<8 + 1
+
+	// BasicTimeMicrosSchemaID is the id for the avro schema of struct BasicTimeMicros (avrox.TimeMicros)
+	BasicTimeMicrosSchemaID SchemaID = 25<<8 + 1
+
+	// BasicTimeNanosSchemaID is the id for the avro schema of struct BasicTimeNanos (avrox.TimeNanos)
+	BasicTimeNanosSchemaID SchemaID = 26<<8 + 1
+
+	// BasicZonedTimeSchemaID is the id for the avro schema of struct BasicZonedTime (avrox.ZonedTime)
+	BasicZonedTimeSchemaID SchemaID = 27<<8 + 1
+
+	// BasicLocalTimestampSchemaID is the id for the avro schema of struct BasicLocalTimestamp (avrox.LocalTimestamp)
+	BasicLocalTimestampSchemaID SchemaID = 28<<8 + 1
+
+	// BasicIPAddrSchemaID is the id for the avro schema of struct BasicIPAddr (netip.Addr)
+	BasicIPAddrSchemaID SchemaID = 29<<8 + 1
+
+	// BasicIPPrefixSchemaID is the id for the avro schema of struct BasicIPPrefix (netip.Prefix)
+	BasicIPPrefixSchemaID SchemaID = 30<<8 + 1
+
+	// BasicURLSchemaID is the id for the avro schema of struct BasicURL (*url.URL)
+	BasicURLSchemaID SchemaID = 31<<8 + 1
+
+	// BasicMACSchemaID is the id for the avro schema of struct BasicMAC (net.HardwareAddr)
+	BasicMACSchemaID SchemaID = 32<<8 + 1
+
+	// BasicEnvelopeSchemaID is the id for the avro schema of struct BasicEnvelope (metadata and a nested message)
+	BasicEnvelopeSchemaID SchemaID = 33<<8 + 1
+
+	// BasicDateRangeSchemaID is the id for the avro schema of struct BasicDateRange (rawdate.Range)
+	BasicDateRangeSchemaID SchemaID = 34<<8 + 1
+
+	// BasicDateRangeSetSchemaID is the id for the avro schema of struct BasicDateRangeSet (rawdate.RangeSet)
+	BasicDateRangeSetSchemaID SchemaID = 35<<8 + 1
+
+	// BasicRecurrenceSchemaID is the id for the avro schema of struct BasicRecurrence (rawdate.Recurrence)
+	BasicRecurrenceSchemaID SchemaID = 36<<8 + 1
+
+	// BasicNullRawDateSchemaID is the id for the avro schema of struct BasicNullRawDate (rawdate.NullRawDate)
+	BasicNullRawDateSchemaID SchemaID = 37<<8 + 1
+
+	// BasicRawTimeSchemaID is the id for the avro schema of struct BasicRawTime (rawdate.RawTime)
+	BasicRawTimeSchemaID SchemaID = 38<<8 + 1
+
+	// BasicRawDateTimeSchemaID is the id for the avro schema of struct BasicRawDateTime (rawdate.RawDateTime)
+	BasicRawDateTimeSchemaID SchemaID = 39<<8 + 1
+
+	// BasicBatchSchemaID is the id for the avro schema of struct BasicBatch (messages of one schema, see MarshalBatch)
+	BasicBatchSchemaID SchemaID = 40<<8 + 1
 )
@@ -0,0 +1,178 @@
+package avrox
+
+import (
+	"errors"
+
+	"github.com/hamba/avro/v2"
+)
+
+var (
+	ErrNoBatch           = sentinel("ErrNoBatch", "data is not a batch")
+	ErrBatchCount        = sentinel("ErrBatchCount", "batch count is invalid")
+	ErrBatchTrailingData = sentinel("ErrBatchTrailingData", "batch has data after its items")
+)
+
+// IsBatch reports whether data starts with the magic of a batch (a BasicBatch message).
+// Decoders which do not know about batches fail with ErrNoBasicSchema instead of reading
+// the batch as single message.
+func IsBatch(data []byte) bool {
+	if len(data) < MagicLen {
+		return false
+	}
+	nID, sID, _, err := DecodeMagic(data[:MagicLen])
+	return err == nil && nID == NamespaceBasic && sID == BasicBatchSchemaID
+}
+
+// MarshalBatch marshals the items into one BasicBatch. The magic of the items is stored
+// once as element, the items only contribute their fields (the items are not modified).
+// The compression runs over the whole batch, the items themselves are not compressed.
+func MarshalBatch[T any, PT interface {
+	*T
+	Schemer
+}](items []T, cID CompressionID) ([]byte, error) {
+	var zero T
+	schema, err := batchBodySchema(PT(&zero))
+	if err != nil {
+		return nil, err
+	}
+	batch := &BasicBatch{Count: len(items)}
+	batch.Element, err = EncodeMagic(PT(&zero).NamespaceID(), PT(&zero).SchemaID(), CompNone)
+	if err != nil {
+		return nil, err
+	}
+	w := avro.NewWriter(nil, 512)
+	for i := range items {
+		if sc, ok := any(PT(&items[i])).(selfCodec); ok {
+			sc.encodeAvro(w)
+		} else {
+			w.WriteVal(schema, PT(&items[i]))
+		}
+		if w.Error != nil {
+			return nil, errors.Join(ErrMarshallingFailed, w.Error)
+		}
+	}
+	batch.Bodies = w.Buffer()
+	return Marshal(batch, cID, nil)
+}
+
+// batchBodySchema returns the schema of the schemer without its leading magic field
+func batchBodySchema(schemer Schemer) (avro.Schema, error) {
+	schema, err := avro.Parse(schemer.Schema())
+	if err != nil {
+		return nil, errors.Join(ErrSchemaInvalid, err)
+	}
+	record, ok := schema.(*avro.RecordSchema)
+	if !ok || len(record.Fields()) == 0 || record.Fields()[0].Name() != MagicFieldName {
+		return nil, ErrMissingMagicField
+	}
+	body, err := avro.NewRecordSchema(record.Name(), record.Namespace(), record.Fields()[1:])
+	if err != nil {
+		return nil, errors.Join(ErrSchemaInvalid, err)
+	}
+	return body, nil
+}
+
+// UnmarshalBatch returns all elements of the batch
+func UnmarshalBatch[T any, PT interface {
+	*T
+	Schemer
+}](data []byte) ([]T, error) {
+	var items []T
+	err := IterateBatch[T, PT](data, func(_ int, item *T) error {
+		items = append(items, *item)
+		return nil
+	})
+	return items, err
+}
+
+// IterateBatch decodes one element after the other and calls fn for it. The element is
+// a new value for each call (with the element magic set). It stops with the error of fn
+// if that is not nil.
+func IterateBatch[T any, PT interface {
+	*T
+	Schemer
+}](data []byte, fn func(idx int, item *T) error) error {
+	if !IsBatch(data) {
+		return ErrNoBatch
+	}
+	var zero T
+	schema, err := batchBodySchema(PT(&zero))
+	if err != nil {
+		return err
+	}
+	element, count, bodies, err := readBatchHeader(data)
+	if err != nil {
+		return err
+	}
+	eNID, eSID, _, err := DecodeMagic(element[:])
+	if err != nil {
+		return err
+	}
+	if eNID != PT(&zero).NamespaceID() {
+		return ErrWrongNamespace
+	}
+	if eSID != PT(&zero).SchemaID() {
+		return ErrWrongSchema
+	}
+	r := avro.NewReader(nil, 0).Reset(bodies)
+	for idx := 0; idx < count; idx++ {
+		item := new(T)
+		if sc, ok := any(PT(item)).(selfCodec); ok {
+			sc.decodeAvro(r)
+		} else {
+			r.ReadVal(schema, PT(item))
+		}
+		if r.Error != nil {
+			return errors.Join(ErrBatchCount, r.Error)
+		}
+		if err = setMagicField(PT(item), element); err != nil {
+			return err
+		}
+		if err = fn(idx, item); err != nil {
+			return err
+		}
+	}
+	if hasMore(r) {
+		return ErrBatchTrailingData
+	}
+	return nil
+}
+
+// readBatchHeader returns the fields of the BasicBatch in data. Bodies is read without
+// copying it (and without the size limit of avro bytes).
+func readBatchHeader(data []byte) ([MagicLen]byte, int, []byte, error) {
+	var element [MagicLen]byte
+	_, _, cID, _ := DecodeMagic(data[:MagicLen])
+	uncompressed, err := DecompressData(data, cID)
+	if err != nil {
+		return element, 0, nil, err
+	}
+	record := uncompressed[MagicLen:]
+	r := avro.NewReader(nil, 0).Reset(record)
+	r.Read(element[:])
+	count := r.ReadInt()
+	size := r.ReadLong()
+	if r.Error != nil || count < 0 || int64(count) > int64(len(record)) || size < 0 {
+		return element, 0, nil, ErrBatchCount
+	}
+	// the header gets written again to find the start of the bodies
+	header := avro.NewWriter(nil, 2*MagicLen)
+	_, _ = header.Write(element[:])
+	header.WriteInt(count)
+	header.WriteLong(size)
+	bodies := record[len(header.Buffer()):]
+	if int64(len(bodies)) < size {
+		return element, 0, nil, ErrBatchCount
+	}
+	if int64(len(bodies)) > size {
+		return element, 0, nil, ErrBatchTrailingData
+	}
+	return element, int(count), bodies, nil
+}
+
+// hasMore reports whether the reader (without an io.Reader) has unread data
+func hasMore(r *avro.Reader) bool {
+	var next [1]byte
+	r.Read(next[:])
+	return r.Error == nil
+}
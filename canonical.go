@@ -0,0 +1,361 @@
+package avrox
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/hamba/avro/v2"
+)
+
+var ErrCanonical = sentinel("ErrCanonical", "can not create canonical encoding")
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// MarshalCanonical works like Marshal but creates a deterministic encoding. Map entries
+// are written in sorted key order, arrays and maps are written as a single block and
+// time.Time values get normalized with AvroTime and AvroDate semantics.
+// The normalization and the magic are applied to a copy, src is not modified.
+// Equal values always result in the same bytes, so the data can be used for hashing,
+// deduplication and signatures.
+func MarshalCanonical(src Schemer, cID CompressionID, schema avro.Schema) ([]byte, error) {
+	if schema == nil {
+		var parseErr error
+		schema, parseErr = avro.Parse(src.Schema())
+		if parseErr != nil {
+			return nil, errors.Join(ErrSchemaInvalid, parseErr)
+		}
+	}
+	value := src
+	if v := reflect.ValueOf(src); v.Kind() == reflect.Ptr && !v.IsNil() {
+		normalized := reflect.New(v.Elem().Type())
+		normalized.Elem().Set(normalizedTimes(v.Elem(), schema))
+		if schemer, ok := normalized.Interface().(Schemer); ok {
+			value = schemer
+		}
+	}
+	// the data gets compressed once after it is canonical
+	data, err := MarshalAny(value, schema, src.NamespaceID(), src.SchemaID(), CompNone)
+	if err != nil {
+		return nil, err
+	}
+	return canonicalMessage(data, schema, cID)
+}
+
+// Canonical re-encodes an existing avrox message into the canonical form (see MarshalCanonical).
+// The schema is taken from the basic types or the given schemers (by the magic of the data).
+// The compression of the message is kept.
+// Time values are already normalized in the avro data, except for dates which got
+// stored from a time.Time that was not in UTC.
+func Canonical(data []byte, schemers ...Schemer) ([]byte, error) {
+	if len(data) < MagicLen {
+		return nil, ErrNotAvroX
+	}
+	nID, sID, cID, err := DecodeMagic(data[:MagicLen])
+	if err != nil {
+		return nil, err
+	}
+	var schema avro.Schema
+	if nID == NamespaceBasic {
+		schema = basicSchema(sID)
+	}
+	for _, schemer := range schemers {
+		if schemer.NamespaceID() == nID && schemer.SchemaID() == sID {
+			var errParse error
+			schema, errParse = avro.Parse(schemer.Schema())
+			if errParse != nil {
+				return nil, errors.Join(ErrSchemaInvalid, errParse)
+			}
+			break
+		}
+	}
+	if schema == nil {
+		return nil, ErrSchemerNotFound
+	}
+	return canonicalMessage(data, schema, cID)
+}
+
+// canonicalMessage re-encodes the avrox message and compresses it with cID
+func canonicalMessage(data []byte, schema avro.Schema, cID CompressionID) ([]byte, error) {
+	uncompressed, nID, sID, err := unmarshalHelper(data)
+	if err != nil {
+		return nil, err
+	}
+	// the magic is part of the avro record (as fixed field)
+	canonical, err := canonicalBody(uncompressed, schema)
+	if err != nil {
+		return nil, err
+	}
+	magic, err := EncodeMagic(nID, sID, cID)
+	if err != nil {
+		return nil, err
+	}
+	copy(canonical, magic[:])
+	return CompressData(canonical, cID)
+}
+
+func canonicalBody(body []byte, schema avro.Schema) ([]byte, error) {
+	r := avro.NewReader(bytes.NewReader(nil), 0).Reset(body)
+	w := avro.NewWriter(nil, len(body))
+	canonicalize(w, r, schema)
+	if r.Error != nil {
+		return nil, errors.Join(ErrCanonical, r.Error)
+	}
+	if w.Error != nil {
+		return nil, errors.Join(ErrCanonical, w.Error)
+	}
+	return w.Buffer(), nil
+}
+
+// canonicalize reads one value of the schema and writes it in canonical form
+func canonicalize(w *avro.Writer, r *avro.Reader, schema avro.Schema) {
+	if r.Error != nil {
+		return
+	}
+	switch s := schema.(type) {
+	case *avro.RefSchema:
+		canonicalize(w, r, s.Schema())
+	case *avro.RecordSchema:
+		for _, f := range s.Fields() {
+			canonicalize(w, r, f.Type())
+		}
+	case *avro.UnionSchema:
+		idx := r.ReadLong()
+		if idx < 0 || int(idx) >= len(s.Types()) {
+			r.ReportError("canonical", "union index out of range")
+			return
+		}
+		w.WriteLong(idx)
+		canonicalize(w, r, s.Types()[idx])
+	case *avro.ArraySchema:
+		var items [][]byte
+		for {
+			count, _ := r.ReadBlockHeader()
+			if count == 0 || r.Error != nil {
+				break
+			}
+			for i := int64(0); i < count; i++ {
+				item := avro.NewWriter(nil, 16)
+				canonicalize(item, r, s.Items())
+				items = append(items, item.Buffer())
+			}
+		}
+		if len(items) > 0 {
+			w.WriteLong(int64(len(items)))
+			for _, item := range items {
+				_, _ = w.Write(item)
+			}
+		}
+		w.WriteLong(0)
+	case *avro.MapSchema:
+		type entry struct {
+			key   string
+			value []byte
+		}
+		var entries []entry
+		for {
+			count, _ := r.ReadBlockHeader()
+			if count == 0 || r.Error != nil {
+				break
+			}
+			for i := int64(0); i < count; i++ {
+				key := r.ReadString()
+				value := avro.NewWriter(nil, 16)
+				canonicalize(value, r, s.Values())
+				entries = append(entries, entry{key: key, value: value.Buffer()})
+			}
+		}
+		sort.Slice(entries, func(i, j int) bool { return entries[i].key < entries[j].key })
+		if len(entries) > 0 {
+			w.WriteLong(int64(len(entries)))
+			for _, e := range entries {
+				w.WriteString(e.key)
+				_, _ = w.Write(e.value)
+			}
+		}
+		w.WriteLong(0)
+	case *avro.FixedSchema:
+		b := make([]byte, s.Size())
+		r.Read(b)
+		_, _ = w.Write(b)
+	case *avro.EnumSchema:
+		w.WriteInt(r.ReadInt())
+	case *avro.NullSchema:
+	default:
+		switch schema.Type() {
+		case avro.Boolean:
+			w.WriteBool(r.ReadBool())
+		case avro.Int:
+			w.WriteInt(r.ReadInt())
+		case avro.Long:
+			w.WriteLong(r.ReadLong())
+		case avro.Float:
+			w.WriteFloat(r.ReadFloat())
+		case avro.Double:
+			w.WriteDouble(r.ReadDouble())
+		case avro.Bytes:
+			w.WriteBytes(r.ReadBytes())
+		case avro.String:
+			w.WriteString(r.ReadString())
+		case avro.Null:
+		default:
+			r.ReportError("canonical", "unsupported schema type "+string(schema.Type()))
+		}
+	}
+}
+
+// NormalizeTimes walks through v (which should be a pointer) and normalizes all
+// time.Time values which are stored as avro date or timestamp with AvroDate and AvroTime
+// (timestamp-micros keeps the microseconds). Times held in interfaces (like the values
+// of a map[string]any) are normalized with the union branch hamba/avro encodes them with.
+func NormalizeTimes(v any, schema avro.Schema) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Ptr && !rv.IsNil() && rv.Elem().CanSet() {
+		rv.Elem().Set(normalizedTimes(rv.Elem(), schema))
+	}
+}
+
+// normalizedTimes returns a copy of v with all times normalized, v itself is not modified
+func normalizedTimes(v reflect.Value, schema avro.Schema) reflect.Value {
+	if ref, ok := schema.(*avro.RefSchema); ok {
+		schema = ref.Schema()
+	}
+	if union, ok := schema.(*avro.UnionSchema); ok {
+		if schema = unionBranch(union, v); schema == nil {
+			return v
+		}
+	}
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return v
+		}
+		out := reflect.New(v.Type().Elem())
+		out.Elem().Set(normalizedTimes(v.Elem(), schema))
+		return out
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		out := reflect.New(v.Type()).Elem()
+		out.Set(normalizedTimes(v.Elem(), schema))
+		return out
+	case reflect.Struct:
+		if v.Type() == timeType {
+			return reflect.ValueOf(normalizeTime(v.Interface().(time.Time), schema))
+		}
+		record, ok := schema.(*avro.RecordSchema)
+		if !ok {
+			return v
+		}
+		out := reflect.New(v.Type()).Elem()
+		out.Set(v)
+		for _, f := range record.Fields() {
+			field := fieldByAvroName(out, f.Name())
+			if field.IsValid() && field.CanSet() {
+				field.Set(normalizedTimes(field, f.Type()))
+			}
+		}
+		return out
+	case reflect.Slice, reflect.Array:
+		array, ok := schema.(*avro.ArraySchema)
+		if !ok || (v.Kind() == reflect.Slice && v.IsNil()) {
+			return v
+		}
+		var out reflect.Value
+		if v.Kind() == reflect.Slice {
+			out = reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		} else {
+			out = reflect.New(v.Type()).Elem()
+		}
+		for i := 0; i < v.Len(); i++ {
+			out.Index(i).Set(normalizedTimes(v.Index(i), array.Items()))
+		}
+		return out
+	case reflect.Map:
+		m, ok := schema.(*avro.MapSchema)
+		if !ok || v.IsNil() {
+			return v
+		}
+		out := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			out.SetMapIndex(iter.Key(), normalizedTimes(iter.Value(), m.Values()))
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+// unionBranch returns the branch of the union v gets encoded with (or nil if it is unknown).
+// Like hamba/avro a time.Time uses the first of date, timestamp-millis and timestamp-micros.
+func unionBranch(union *avro.UnionSchema, v reflect.Value) avro.Schema {
+	if (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) && v.IsNil() {
+		return nil
+	}
+	if union.Nullable() {
+		_, typ := union.Indices()
+		return union.Types()[typ]
+	}
+	if v.Kind() == reflect.Interface {
+		v = v.Elem()
+	}
+	var names []string
+	switch {
+	case v.Type() == timeType:
+		names = []string{"int.date", "long.timestamp-millis", "long.timestamp-micros"}
+	case v.Kind() == reflect.Map:
+		names = []string{"map"}
+	case v.Kind() == reflect.Slice && v.Type().Elem().Kind() != reflect.Uint8:
+		names = []string{"array"}
+	}
+	for _, name := range names {
+		if branch, _ := union.Types().Get(name); branch != nil {
+			return branch
+		}
+	}
+	return nil
+}
+
+func normalizeTime(t time.Time, schema avro.Schema) time.Time {
+	ps, ok := schema.(*avro.PrimitiveSchema)
+	if !ok || ps.Logical() == nil {
+		return t
+	}
+	//nolint:exhaustive // only time types
+	switch ps.Logical().Type() {
+	case avro.Date:
+		return AvroDate(t)
+	case avro.TimestampMillis:
+		return AvroTime(t)
+	case avro.TimestampMicros:
+		return AvroTimeMicros(t)
+	case avro.LocalTimestampMillis:
+		return t.Truncate(time.Millisecond)
+	case avro.LocalTimestampMicros:
+		return t.Truncate(time.Microsecond)
+	default:
+		return t
+	}
+}
+
+// fieldByAvroName finds the struct field for the avro field name (using the avro tag like hamba/avro)
+func fieldByAvroName(v reflect.Value, name string) reflect.Value {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if tag, ok := sf.Tag.Lookup("avro"); ok {
+			if tag == name {
+				return v.Field(i)
+			}
+			continue
+		}
+		if sf.Name == name {
+			return v.Field(i)
+		}
+	}
+	return reflect.Value{}
+}
@@ -0,0 +1,23 @@
+package avrox
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// ContentID returns a stable hash for an avrox message. It covers the namespace,
+// the schema (without its version) and the uncompressed avro data, so the compression
+// and the parity byte of the magic do not change the result.
+// The id is only stable when the encoding is deterministic (maps get encoded in
+// iteration order by avro). Use MarshalCanonical or Canonical for those.
+func ContentID(data []byte) (string, error) {
+	uncompressed, nID, sID, err := unmarshalHelper(data)
+	if err != nil {
+		return "", err
+	}
+	schema, _ := UnpackSchemVer(sID)
+	h := sha256.New()
+	h.Write([]byte{byte(nID >> 8), byte(nID), byte(schema >> 8), byte(schema)})
+	h.Write(uncompressed[MagicLen:])
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
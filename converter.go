@@ -1,6 +1,10 @@
 package avrox
 
-import "time"
+import (
+	"time"
+
+	"github.com/metatexx/avrox/rawdate"
+)
 
 // AvroTime truncates a go time.Time to the value that gets stored the avro logicalTime (which has a granularity of milliseconds while go has nanoseconds)
 // It also makes sure that the time is expressed in UTC()
@@ -13,3 +17,14 @@ func AvroTime(t time.Time) time.Time {
 func AvroDate(t time.Time) time.Time {
 	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
 }
+
+// AvroNow returns the current time of the rawdate package clock (see rawdate.SetClock) as AvroTime.
+// Use it instead of AvroTime(time.Now()) to make code testable with a rawdate.FakeClock.
+func AvroNow() time.Time {
+	return AvroTime(rawdate.CurrentClock().Now())
+}
+
+// AvroToday returns the current date (in loc) of the rawdate package clock as AvroDate
+func AvroToday(loc *time.Location) time.Time {
+	return AvroDate(rawdate.TodayIn(loc).Time(time.UTC))
+}
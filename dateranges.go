@@ -0,0 +1,47 @@
+package avrox
+
+import (
+	"github.com/metatexx/avrox/rawdate"
+)
+
+// DateRange is the avro representation of a rawdate.Range. The dates are stored as avro
+// date (rawdate.Days). It can be used as field in own records with the schema:
+//
+//	{"type":"record","name":"DateRange","fields":[
+//	  {"name":"Start","type":{"type":"int","logicalType":"date"}},
+//	  {"name":"End","type":{"type":"int","logicalType":"date"}},
+//	  {"name":"HalfOpen","type":"boolean"}]}
+type DateRange struct {
+	Start    rawdate.Days
+	End      rawdate.Days
+	HalfOpen bool
+}
+
+// NewDateRange converts the rawdate.Range
+func NewDateRange(r rawdate.Range) DateRange {
+	return DateRange{Start: r.Start.Days(), End: r.End.Days(), HalfOpen: r.HalfOpen}
+}
+
+// Range returns the rawdate.Range
+func (d DateRange) Range() rawdate.Range {
+	return rawdate.Range{Start: d.Start.RawDate(), End: d.End.RawDate(), HalfOpen: d.HalfOpen}
+}
+
+// NewDateRanges converts the (normalized) ranges of the rawdate.RangeSet
+func NewDateRanges(s rawdate.RangeSet) []DateRange {
+	ranges := s.Ranges()
+	result := make([]DateRange, len(ranges))
+	for i, r := range ranges {
+		result[i] = NewDateRange(r)
+	}
+	return result
+}
+
+// DateRangeSet returns the rawdate.RangeSet of the ranges
+func DateRangeSet(ranges []DateRange) rawdate.RangeSet {
+	rs := make([]rawdate.Range, len(ranges))
+	for i, r := range ranges {
+		rs[i] = r.Range()
+	}
+	return rawdate.NewRangeSet(rs...)
+}
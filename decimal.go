@@ -0,0 +1,205 @@
+package avrox
+
+import (
+	"math/big"
+	"strings"
+)
+
+// MaxDecimalScale is the largest scale a Decimal can have
+const MaxDecimalScale = 100
+
+var (
+	ErrDecimalInexact  = sentinel("ErrDecimalInexact", "decimal can not be represented exactly with the scale")
+	ErrDecimalScale    = sentinel("ErrDecimalScale", "decimal scale must be between 0 and 100")
+	ErrDecimalInvalid  = sentinel("ErrDecimalInvalid", "decimal is invalid")
+	ErrDecimalOverflow = sentinel("ErrDecimalOverflow", "decimal exceeds the precision")
+)
+
+var (
+	bigOne = big.NewInt(1)
+	bigTen = big.NewInt(10)
+)
+
+// Decimal is an exact decimal number with an explicit scale (Unscaled * 10^-Scale).
+// It gets stored with its scale in the payload (see BasicDecimalScaled), so values
+// with different scales (prices, exchange rates, quantities) can use the same schema.
+// The zero value is 0 with a scale of 0.
+type Decimal struct {
+	Unscaled *big.Int
+	Scale    int
+}
+
+// NewDecimal rounds r (half to even) to the scale
+func NewDecimal(r *big.Rat, scale int) (Decimal, error) {
+	if scale < 0 || scale > MaxDecimalScale {
+		return Decimal{}, ErrDecimalScale
+	}
+	num := new(big.Int).Mul(r.Num(), pow10(scale))
+	quo, rem := new(big.Int).QuoRem(num, r.Denom(), new(big.Int))
+	// compare 2*|rem| with the denominator to round half to even
+	switch new(big.Int).Abs(new(big.Int).Lsh(rem, 1)).Cmp(r.Denom()) {
+	case 1:
+		quo.Add(quo, big.NewInt(int64(num.Sign())))
+	case 0:
+		if quo.Bit(0) == 1 {
+			quo.Add(quo, big.NewInt(int64(num.Sign())))
+		}
+	}
+	return Decimal{Unscaled: quo, Scale: scale}, nil
+}
+
+// NewDecimalExact is the strict version of NewDecimal. It returns ErrDecimalInexact
+// if r can not be represented with the scale without rounding.
+func NewDecimalExact(r *big.Rat, scale int) (Decimal, error) {
+	if scale < 0 || scale > MaxDecimalScale {
+		return Decimal{}, ErrDecimalScale
+	}
+	num := new(big.Int).Mul(r.Num(), pow10(scale))
+	quo, rem := new(big.Int).QuoRem(num, r.Denom(), new(big.Int))
+	if rem.Sign() != 0 {
+		return Decimal{}, ErrDecimalInexact
+	}
+	return Decimal{Unscaled: quo, Scale: scale}, nil
+}
+
+// DecimalFromRat returns the Decimal with the smallest scale that represents r exactly.
+// It returns ErrDecimalInexact for values like 1/3.
+func DecimalFromRat(r *big.Rat) (Decimal, error) {
+	for scale := 0; scale <= MaxDecimalScale; scale++ {
+		if d, err := NewDecimalExact(r, scale); err == nil {
+			return d, nil
+		}
+	}
+	return Decimal{}, ErrDecimalInexact
+}
+
+// ParseDecimal parses a decimal like "-123.4500". The scale is the number of fractional digits.
+func ParseDecimal(s string) (Decimal, error) {
+	digits := strings.TrimLeft(s, "+-")
+	if len(digits) == 0 || len(s)-len(digits) > 1 {
+		return Decimal{}, ErrDecimalInvalid
+	}
+	scale := 0
+	if idx := strings.IndexByte(digits, '.'); idx >= 0 {
+		scale = len(digits) - idx - 1
+		digits = digits[:idx] + digits[idx+1:]
+	}
+	if scale > MaxDecimalScale {
+		return Decimal{}, ErrDecimalScale
+	}
+	if len(digits) == 0 || strings.Trim(digits, "0123456789") != "" {
+		return Decimal{}, ErrDecimalInvalid
+	}
+	unscaled, _ := new(big.Int).SetString(digits, 10)
+	if strings.HasPrefix(s, "-") {
+		unscaled.Neg(unscaled)
+	}
+	return Decimal{Unscaled: unscaled, Scale: scale}, nil
+}
+
+// MustParseDecimal parses the decimal and panics on errors
+func MustParseDecimal(s string) Decimal {
+	d, err := ParseDecimal(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// Rat returns the value as *big.Rat
+func (d Decimal) Rat() *big.Rat {
+	return new(big.Rat).SetFrac(d.unscaled(), pow10(d.Scale))
+}
+
+// String returns the decimal with all digits of its scale (e.g. "1.50")
+func (d Decimal) String() string {
+	return d.Rat().FloatString(d.Scale)
+}
+
+// Precision returns the number of digits of the unscaled value
+func (d Decimal) Precision() int {
+	if d.unscaled().Sign() == 0 {
+		return 1
+	}
+	return len(new(big.Int).Abs(d.unscaled()).String())
+}
+
+// Cmp compares the values (ignoring the scale) and returns -1, 0 or +1
+func (d Decimal) Cmp(o Decimal) int {
+	return d.Rat().Cmp(o.Rat())
+}
+
+// Rescale returns the value with another scale (rounding half to even when it gets smaller)
+func (d Decimal) Rescale(scale int) (Decimal, error) {
+	return NewDecimal(d.Rat(), scale)
+}
+
+func (d Decimal) unscaled() *big.Int {
+	if d.Unscaled == nil {
+		return new(big.Int)
+	}
+	return d.Unscaled
+}
+
+// CheckDecimal returns an error if r can not be stored exactly as avro decimal
+// with the precision and scale (like BasicDecimal with 17 and 4).
+func CheckDecimal(r *big.Rat, precision, scale int) error {
+	d, err := NewDecimalExact(r, scale)
+	if err != nil {
+		return err
+	}
+	if d.Precision() > precision {
+		return ErrDecimalOverflow
+	}
+	return nil
+}
+
+// MarshalDecimalStrict marshals r as BasicDecimal, but returns an error instead of
+// rounding or overflowing (use BasicDecimalScaled for other scales).
+func MarshalDecimalStrict(r *big.Rat, cID CompressionID) ([]byte, error) {
+	if err := CheckDecimal(r, BasicDecimalPrecision, BasicDecimalScale); err != nil {
+		return nil, err
+	}
+	return MarshalBasic(r, cID)
+}
+
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(bigTen, big.NewInt(int64(n)), nil)
+}
+
+// intBytes returns the two's-complement big-endian representation (like the avro decimal)
+func intBytes(i *big.Int) []byte {
+	switch i.Sign() {
+	case 0:
+		return []byte{0}
+	case 1:
+		b := i.Bytes()
+		if b[0]&0x80 > 0 {
+			b = append([]byte{0}, b...)
+		}
+		return b
+	default:
+		length := uint(i.BitLen()/8+1) * 8
+		return new(big.Int).Add(i, new(big.Int).Lsh(bigOne, length)).Bytes()
+	}
+}
+
+// bytesInt is the reverse of intBytes
+func bytesInt(b []byte) *big.Int {
+	i := new(big.Int).SetBytes(b)
+	if len(b) > 0 && b[0]&0x80 > 0 {
+		i.Sub(i, new(big.Int).Lsh(bigOne, uint(len(b))*8))
+	}
+	return i
+}
+
+// decimalBytes returns the unscaled bytes of r for the scale (truncated like hamba/avro does)
+func decimalBytes(r *big.Rat, scale int) []byte {
+	i := new(big.Int).Mul(r.Num(), pow10(scale))
+	return intBytes(i.Quo(i, r.Denom()))
+}
+
+// decimalRat is the reverse of decimalBytes
+func decimalRat(b []byte, scale int) *big.Rat {
+	return new(big.Rat).SetFrac(bytesInt(b), pow10(scale))
+}
@@ -0,0 +1,88 @@
+package avrox
+
+import (
+	"time"
+)
+
+// Meta is the metadata of an envelope (see BasicEnvelope)
+type Meta struct {
+	ProducerID    string
+	EventTime     time.Time // stored with microseconds
+	TraceID       string
+	SpanID        string
+	CorrelationID string
+	Values        map[string]string // everything else
+}
+
+// Envelope is a nested avrox message with metadata. It gets stored with BasicEnvelope.
+type Envelope struct {
+	Meta    Meta
+	Payload []byte // a complete avrox message (with its own magic)
+}
+
+// Wrap marshals inner (with the compression) and puts it into an envelope with the metadata.
+// The envelope itself is not compressed.
+func Wrap(inner Schemer, meta Meta, cID CompressionID) ([]byte, error) {
+	payload, err := Marshal(inner, cID, nil)
+	if err != nil {
+		return nil, err
+	}
+	return WrapData(payload, meta)
+}
+
+// WrapData puts an already marshalled avrox message into an envelope with the metadata
+func WrapData(payload []byte, meta Meta) ([]byte, error) {
+	if len(payload) < MagicLen || !IsMagic(payload[:MagicLen]) {
+		return nil, ErrNotAvroX
+	}
+	return MarshalBasic(Envelope{Meta: meta, Payload: payload}, CompNone)
+}
+
+// Unwrap returns the metadata and the inner message. The type of the inner message
+// is selected by its magic from the registry (which can be nil for basic types).
+func Unwrap(data []byte, registry *Registry) (Meta, Schemer, error) {
+	envelope, err := UnmarshalEnvelope(data)
+	if err != nil {
+		return Meta{}, nil, err
+	}
+	inner, err := registry.Unmarshal(envelope.Payload)
+	if err != nil {
+		return Meta{}, nil, err
+	}
+	return envelope.Meta, inner, nil
+}
+
+// Envelope returns the stored value
+func (b BasicEnvelope) Envelope() Envelope {
+	var values map[string]string
+	if len(b.Meta) > 0 {
+		values = b.Meta
+	}
+	// keep the zero time comparable with IsZero() and ==
+	eventTime := b.EventTime.UTC()
+	if eventTime.Equal(time.Time{}) {
+		eventTime = time.Time{}
+	}
+	return Envelope{
+		Meta: Meta{
+			ProducerID:    b.ProducerID,
+			EventTime:     eventTime,
+			TraceID:       b.TraceID,
+			SpanID:        b.SpanID,
+			CorrelationID: b.CorrelationID,
+			Values:        values,
+		},
+		Payload: b.Value,
+	}
+}
+
+// SetEnvelope stores the value
+func (b *BasicEnvelope) SetEnvelope(e Envelope) {
+	b.ProducerID = e.Meta.ProducerID
+	b.EventTime = e.Meta.EventTime
+	b.TraceID = e.Meta.TraceID
+	b.SpanID = e.Meta.SpanID
+	b.CorrelationID = e.Meta.CorrelationID
+	b.Meta = e.Meta.Values
+	b.Value = e.Payload
+}
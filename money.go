@@ -0,0 +1,83 @@
+package avrox
+
+import (
+	"math/big"
+)
+
+var ErrCurrencyInvalid = sentinel("ErrCurrencyInvalid", "currency must be an ISO 4217 code")
+
+// currencyMinorUnits contains the ISO 4217 currencies which do not have 2 minor units
+var currencyMinorUnits = map[string]int{
+	"BIF": 0, "CLP": 0, "DJF": 0, "GNF": 0, "ISK": 0, "JPY": 0, "KMF": 0, "KRW": 0, "PYG": 0,
+	"RWF": 0, "UGX": 0, "UYI": 0, "VND": 0, "VUV": 0, "XAF": 0, "XOF": 0, "XPF": 0,
+	"BHD": 3, "IQD": 3, "JOD": 3, "KWD": 3, "LYD": 3, "OMR": 3, "TND": 3,
+	"CLF": 4, "UYW": 4,
+}
+
+// Money is an amount in a currency (ISO 4217 code like "EUR")
+type Money struct {
+	Amount   Decimal
+	Currency string
+}
+
+// NewMoney creates Money with the minor units of the currency as scale (e.g. 2 for EUR
+// and 0 for JPY). It returns ErrDecimalInexact if the amount has more fractional digits.
+func NewMoney(amount *big.Rat, currency string) (Money, error) {
+	if !ValidCurrency(currency) {
+		return Money{}, ErrCurrencyInvalid
+	}
+	d, err := NewDecimalExact(amount, CurrencyMinorUnits(currency))
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{Amount: d, Currency: currency}, nil
+}
+
+// ParseMoney parses the amount (keeping its scale, so "1.2345" can be used for unit prices)
+func ParseMoney(amount, currency string) (Money, error) {
+	if !ValidCurrency(currency) {
+		return Money{}, ErrCurrencyInvalid
+	}
+	d, err := ParseDecimal(amount)
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{Amount: d, Currency: currency}, nil
+}
+
+// ValidCurrency reports whether code has the form of an ISO 4217 code (three uppercase letters)
+func ValidCurrency(code string) bool {
+	if len(code) != 3 {
+		return false
+	}
+	for i := 0; i < 3; i++ {
+		if code[i] < 'A' || code[i] > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+// CurrencyMinorUnits returns the number of minor units (fractional digits) of the currency
+func CurrencyMinorUnits(code string) int {
+	if units, found := currencyMinorUnits[code]; found {
+		return units
+	}
+	return 2
+}
+
+// Validate checks the currency code and the scale of the amount
+func (m Money) Validate() error {
+	if !ValidCurrency(m.Currency) {
+		return ErrCurrencyInvalid
+	}
+	if m.Amount.Scale < 0 || m.Amount.Scale > MaxDecimalScale {
+		return ErrDecimalScale
+	}
+	return nil
+}
+
+// String returns the amount followed by the currency (e.g. "12.50 EUR")
+func (m Money) String() string {
+	return m.Amount.String() + " " + m.Currency
+}
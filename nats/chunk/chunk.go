@@ -0,0 +1,358 @@
+// Package chunk splits large avrox payloads into multiple NATS messages and
+// reassembles them on the receiving side.
+//
+// Every chunk carries headers with the id of the payload, its index and the
+// total count. The last chunk also carries the SHA-256 checksum of the
+// complete payload. Payloads that fit into a single message are sent
+// without chunk headers, so small messages stay compatible with plain subscribers.
+package chunk
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/hamba/avro/v2"
+	"github.com/metatexx/avrox"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nuid"
+)
+
+// Headers for the chunked messages
+const (
+	HeaderID       = "Avrox-Chunk-Id"
+	HeaderIndex    = "Avrox-Chunk-Index" // zero based
+	HeaderCount    = "Avrox-Chunk-Count"
+	HeaderChecksum = "Avrox-Chunk-Checksum" // hex encoded sha256 (last chunk only)
+)
+
+// HeaderReserve is subtracted from the max payload of the connection for the chunk headers
+const HeaderReserve = 512
+
+var (
+	ErrChunkSize       = errors.New("chunk: chunk size must be positive")
+	ErrChunkHeader     = errors.New("chunk: invalid chunk headers")
+	ErrChecksum        = errors.New("chunk: checksum mismatch")
+	ErrTooLarge        = errors.New("chunk: payload exceeds the size limit")
+	ErrTooManyPending  = errors.New("chunk: too many pending assemblies")
+	ErrTooManyChunks   = errors.New("chunk: chunk count exceeds the limit")
+	ErrAssemblyTimeout = errors.New("chunk: assembly timed out")
+)
+
+// TimeoutError is given to the Handler for partial assemblies that timed out.
+// It matches ErrAssemblyTimeout with errors.Is.
+type TimeoutError struct {
+	ID string // value of HeaderID
+}
+
+func (e *TimeoutError) Error() string {
+	return ErrAssemblyTimeout.Error() + ": " + e.ID
+}
+
+func (e *TimeoutError) Unwrap() error {
+	return ErrAssemblyTimeout
+}
+
+// Publisher is used to publish the chunks (*nats.Conn implements it)
+type Publisher interface {
+	PublishMsg(msg *nats.Msg) error
+}
+
+// ChunkSize returns the usable chunk size for the connection
+func ChunkSize(nc *nats.Conn) int {
+	return int(nc.MaxPayload()) - HeaderReserve
+}
+
+// Split creates the messages for the data. If the data fits into one chunk,
+// a single message without chunk headers is returned.
+func Split(subject string, data []byte, chunkSize int) ([]*nats.Msg, error) {
+	if chunkSize <= 0 {
+		return nil, ErrChunkSize
+	}
+	if len(data) <= chunkSize {
+		msg := nats.NewMsg(subject)
+		msg.Data = data
+		return []*nats.Msg{msg}, nil
+	}
+	id := nuid.Next()
+	count := (len(data) + chunkSize - 1) / chunkSize
+	sum := sha256.Sum256(data)
+	msgs := make([]*nats.Msg, 0, count)
+	for idx := 0; idx < count; idx++ {
+		end := (idx + 1) * chunkSize
+		if end > len(data) {
+			end = len(data)
+		}
+		msg := nats.NewMsg(subject)
+		msg.Data = data[idx*chunkSize : end]
+		msg.Header.Set(HeaderID, id)
+		msg.Header.Set(HeaderIndex, strconv.Itoa(idx))
+		msg.Header.Set(HeaderCount, strconv.Itoa(count))
+		if idx == count-1 {
+			msg.Header.Set(HeaderChecksum, hex.EncodeToString(sum[:]))
+		}
+		msgs = append(msgs, msg)
+	}
+	return msgs, nil
+}
+
+// PublishData publishes the data in chunks of chunkSize
+func PublishData(pub Publisher, subject string, data []byte, chunkSize int) error {
+	msgs, err := Split(subject, data, chunkSize)
+	if err != nil {
+		return err
+	}
+	for _, msg := range msgs {
+		if err = pub.PublishMsg(msg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Publish marshals the schemer and publishes it in chunks of chunkSize
+func Publish(pub Publisher, subject string, src avrox.Schemer, cID avrox.CompressionID, chunkSize int) error {
+	data, err := avrox.Marshal(src, cID, nil)
+	if err != nil {
+		return err
+	}
+	return PublishData(pub, subject, data, chunkSize)
+}
+
+// Default limits of the Config
+const (
+	DefaultTimeout    = 30 * time.Second
+	DefaultMaxPending = 64
+	DefaultMaxChunks  = 1024
+	DefaultMaxSize    = 64 << 20
+)
+
+// Config limits the resources of an Assembler
+type Config struct {
+	// Timeout drops partial assemblies that did not get a chunk for this long (0 means 30s)
+	Timeout time.Duration
+	// MaxPending is the max number of concurrent partial assemblies (0 means 64)
+	MaxPending int
+	// MaxChunks is the max chunk count of a payload, larger counts are rejected before
+	// anything gets allocated (0 means 1024)
+	MaxChunks int
+	// MaxSize is the max size of a reassembled payload (0 means 64 MiB, negative means unlimited)
+	MaxSize int
+	// Now is the clock for the timeouts (nil means time.Now)
+	Now func() time.Time
+}
+
+type assembly struct {
+	chunks   [][]byte
+	received int
+	size     int
+	checksum string
+	last     time.Time
+}
+
+// Assembler collects the chunks and returns the payloads when they are complete.
+// Partial assemblies are only dropped by Expire, which should be called regularly
+// (Subscribe does that with a ticker). It is safe for concurrent use.
+type Assembler struct {
+	cfg     Config
+	mu      sync.Mutex
+	pending map[string]*assembly
+	// done remembers the completed (or failed) ids for the timeout, so late duplicates
+	// of their chunks do not start new assemblies
+	done map[string]time.Time
+}
+
+// NewAssembler creates an Assembler with the given limits
+func NewAssembler(cfg Config) *Assembler {
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = DefaultTimeout
+	}
+	if cfg.MaxPending <= 0 {
+		cfg.MaxPending = DefaultMaxPending
+	}
+	if cfg.MaxChunks <= 0 {
+		cfg.MaxChunks = DefaultMaxChunks
+	}
+	if cfg.MaxSize == 0 {
+		cfg.MaxSize = DefaultMaxSize
+	}
+	if cfg.Now == nil {
+		cfg.Now = time.Now
+	}
+	return &Assembler{cfg: cfg, pending: map[string]*assembly{}, done: map[string]time.Time{}}
+}
+
+// Pending returns the number of partial assemblies
+func (a *Assembler) Pending() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return len(a.pending)
+}
+
+// Expire drops the partial assemblies that timed out and returns their ids
+func (a *Assembler) Expire() []string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.expire(a.cfg.Now())
+}
+
+func (a *Assembler) expire(now time.Time) []string {
+	var expired []string
+	for id, as := range a.pending {
+		if now.Sub(as.last) > a.cfg.Timeout {
+			delete(a.pending, id)
+			a.done[id] = now
+			expired = append(expired, id)
+		}
+	}
+	for id, at := range a.done {
+		if now.Sub(at) > a.cfg.Timeout {
+			delete(a.done, id)
+		}
+	}
+	return expired
+}
+
+// finish removes the assembly and remembers its id
+func (a *Assembler) finish(id string, now time.Time) {
+	delete(a.pending, id)
+	a.done[id] = now
+}
+
+// Add adds a message. It returns the complete payload and true when the message
+// was not chunked or when it was the last missing chunk. Chunks of ids which completed,
+// failed or timed out within the timeout are ignored.
+func (a *Assembler) Add(msg *nats.Msg) ([]byte, bool, error) {
+	id := msg.Header.Get(HeaderID)
+	if id == "" {
+		if a.cfg.MaxSize > 0 && len(msg.Data) > a.cfg.MaxSize {
+			return nil, false, ErrTooLarge
+		}
+		return msg.Data, true, nil
+	}
+	idx, errIdx := strconv.Atoi(msg.Header.Get(HeaderIndex))
+	count, errCount := strconv.Atoi(msg.Header.Get(HeaderCount))
+	if errIdx != nil || errCount != nil || count <= 0 || idx < 0 || idx >= count {
+		return nil, false, ErrChunkHeader
+	}
+	if count > a.cfg.MaxChunks {
+		return nil, false, ErrTooManyChunks
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	now := a.cfg.Now()
+	if _, done := a.done[id]; done {
+		return nil, false, nil
+	}
+
+	as, found := a.pending[id]
+	if !found {
+		if len(a.pending) >= a.cfg.MaxPending {
+			return nil, false, ErrTooManyPending
+		}
+		as = &assembly{chunks: make([][]byte, count)}
+		a.pending[id] = as
+	}
+	if len(as.chunks) != count {
+		a.finish(id, now)
+		return nil, false, ErrChunkHeader
+	}
+	as.last = now
+	if as.chunks[idx] == nil {
+		as.chunks[idx] = msg.Data
+		as.received++
+		as.size += len(msg.Data)
+	}
+	if checksum := msg.Header.Get(HeaderChecksum); checksum != "" {
+		as.checksum = checksum
+	}
+	if a.cfg.MaxSize > 0 && as.size > a.cfg.MaxSize {
+		a.finish(id, now)
+		return nil, false, ErrTooLarge
+	}
+	if as.received < count {
+		return nil, false, nil
+	}
+
+	a.finish(id, now)
+	data := make([]byte, 0, as.size)
+	for _, c := range as.chunks {
+		data = append(data, c...)
+	}
+	sum := sha256.Sum256(data)
+	if as.checksum != hex.EncodeToString(sum[:]) {
+		return nil, false, ErrChecksum
+	}
+	return data, true, nil
+}
+
+// Handler gets called with the reassembled and decoded value. The msg is the
+// message that completed the payload. Errors of the reassembly or decoding
+// are given with a nil value. Timed out assemblies are reported with a nil msg
+// and a *TimeoutError.
+type Handler[T any] func(msg *nats.Msg, value *T, err error)
+
+// Subscribe subscribes to the subject and calls the handler for every reassembled
+// payload which gets decoded into T (where *T is an avrox.Schemer).
+// The partial assemblies are expired by a goroutine which ends with the subscription
+// (however it gets closed). It does not use the closed handler of the subscription,
+// so the caller is free to set one.
+func Subscribe[T any, PT interface {
+	*T
+	avrox.Schemer
+}](nc *nats.Conn, subject string, cfg Config, handler Handler[T]) (*nats.Subscription, error) {
+	var zero T
+	schema, errParse := avro.Parse(PT(&zero).Schema())
+	if errParse != nil {
+		return nil, errors.Join(avrox.ErrSchemaInvalid, errParse)
+	}
+	asm := NewAssembler(cfg)
+	// the handler gets called from the subscription and from the expiry ticker
+	var handlerMu sync.Mutex
+	handle := func(msg *nats.Msg, value *T, err error) {
+		handlerMu.Lock()
+		defer handlerMu.Unlock()
+		handler(msg, value, err)
+	}
+	sub, err := nc.Subscribe(subject, func(msg *nats.Msg) {
+		data, complete, err := asm.Add(msg)
+		if err != nil {
+			handle(msg, nil, err)
+			return
+		}
+		if !complete {
+			return
+		}
+		value := new(T)
+		if err = avrox.Unmarshal(data, PT(value), schema); err != nil {
+			handle(msg, nil, err)
+			return
+		}
+		handle(msg, value, nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+	go expireEvery(asm, max(asm.cfg.Timeout/2, time.Millisecond), sub.IsValid, func(id string) {
+		handle(nil, nil, &TimeoutError{ID: id})
+	})
+	return sub, nil
+}
+
+// expireEvery runs the expiry of the assembler as long as valid returns true
+func expireEvery(asm *Assembler, interval time.Duration, valid func() bool, expired func(id string)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for range ticker.C {
+		if !valid() {
+			return
+		}
+		for _, id := range asm.Expire() {
+			expired(id)
+		}
+	}
+}
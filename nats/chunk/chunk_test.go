@@ -0,0 +1,144 @@
+package chunk_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/metatexx/avrox"
+	"github.com/metatexx/avrox/nats/chunk"
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakePublisher struct {
+	msgs []*nats.Msg
+}
+
+func (p *fakePublisher) PublishMsg(msg *nats.Msg) error {
+	p.msgs = append(p.msgs, msg)
+	return nil
+}
+
+func TestChunks(t *testing.T) {
+	value := &avrox.BasicByteSlice{Value: bytes.Repeat([]byte("0123456789"), 100)}
+	pub := &fakePublisher{}
+	err := chunk.Publish(pub, "files", value, avrox.CompNone, 64)
+	assert.NoError(t, err)
+	assert.Len(t, pub.msgs, 16) // 8 magic + 2 length + 1000 bytes
+	assert.Equal(t, "15", pub.msgs[15].Header.Get(chunk.HeaderIndex))
+	assert.Equal(t, "16", pub.msgs[0].Header.Get(chunk.HeaderCount))
+	assert.Empty(t, pub.msgs[0].Header.Get(chunk.HeaderChecksum))
+	assert.NotEmpty(t, pub.msgs[15].Header.Get(chunk.HeaderChecksum))
+
+	// deliver out of order
+	asm := chunk.NewAssembler(chunk.Config{})
+	var data []byte
+	for idx := len(pub.msgs) - 1; idx >= 0; idx-- {
+		var complete bool
+		data, complete, err = asm.Add(pub.msgs[idx])
+		assert.NoError(t, err)
+		assert.Equal(t, idx == 0, complete)
+	}
+	assert.Equal(t, 0, asm.Pending())
+	decoded := &avrox.BasicByteSlice{}
+	assert.NoError(t, avrox.Unmarshal(data, decoded, nil))
+	assert.Equal(t, value.Value, decoded.Value)
+
+	// small payloads are not chunked
+	pub = &fakePublisher{}
+	err = chunk.Publish(pub, "files", &avrox.BasicString{Value: "foo"}, avrox.CompNone, 64)
+	assert.NoError(t, err)
+	assert.Len(t, pub.msgs, 1)
+	assert.Empty(t, pub.msgs[0].Header.Get(chunk.HeaderID))
+	data, complete, err := asm.Add(pub.msgs[0])
+	assert.NoError(t, err)
+	assert.True(t, complete)
+	assert.Equal(t, pub.msgs[0].Data, data)
+}
+
+func TestChunkErrors(t *testing.T) {
+	payload := bytes.Repeat([]byte("x"), 100)
+	msgs, err := chunk.Split("files", payload, 10)
+	assert.NoError(t, err)
+
+	_, err = chunk.Split("files", payload, 0)
+	assert.ErrorIs(t, err, chunk.ErrChunkSize)
+
+	// checksum
+	msgs[3].Data = []byte("yyyyyyyyyy")
+	asm := chunk.NewAssembler(chunk.Config{})
+	for _, msg := range msgs {
+		_, _, err = asm.Add(msg)
+	}
+	assert.ErrorIs(t, err, chunk.ErrChecksum)
+
+	// size limit
+	asm = chunk.NewAssembler(chunk.Config{MaxSize: 50})
+	for _, msg := range msgs {
+		if _, _, err = asm.Add(msg); err != nil {
+			break
+		}
+	}
+	assert.ErrorIs(t, err, chunk.ErrTooLarge)
+	assert.Equal(t, 0, asm.Pending())
+
+	// pending limit
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+	asm = chunk.NewAssembler(chunk.Config{MaxPending: 1, Timeout: 10 * time.Second, Now: clock})
+	other, err := chunk.Split("files", payload, 10)
+	assert.NoError(t, err)
+	_, _, err = asm.Add(msgs[0])
+	assert.NoError(t, err)
+	_, _, err = asm.Add(other[0])
+	assert.ErrorIs(t, err, chunk.ErrTooManyPending)
+
+	// timeout
+	now = now.Add(5 * time.Second)
+	assert.Empty(t, asm.Expire())
+	now = now.Add(6 * time.Second)
+	assert.Equal(t, []string{msgs[0].Header.Get(chunk.HeaderID)}, asm.Expire())
+	_, _, err = asm.Add(other[0])
+	assert.NoError(t, err)
+
+	// late chunks of the expired id do not start a new assembly
+	_, complete, err := asm.Add(msgs[1])
+	assert.NoError(t, err)
+	assert.False(t, complete)
+	assert.Equal(t, 1, asm.Pending())
+
+	// neither do duplicates of completed ids
+	for _, msg := range other[1:] {
+		_, complete, err = asm.Add(msg)
+		assert.NoError(t, err)
+	}
+	assert.True(t, complete)
+	_, complete, err = asm.Add(other[0])
+	assert.NoError(t, err)
+	assert.False(t, complete)
+	assert.Equal(t, 0, asm.Pending())
+
+	// the ids are forgotten after the timeout
+	now = now.Add(11 * time.Second)
+	assert.Empty(t, asm.Expire())
+	_, _, err = asm.Add(other[0])
+	assert.NoError(t, err)
+	assert.Equal(t, 1, asm.Pending())
+
+	// chunk count limit (checked before anything gets allocated)
+	asm = chunk.NewAssembler(chunk.Config{MaxChunks: 5})
+	_, _, err = asm.Add(msgs[0])
+	assert.ErrorIs(t, err, chunk.ErrTooManyChunks)
+	huge := nats.NewMsg("files")
+	huge.Header.Set(chunk.HeaderID, "huge")
+	huge.Header.Set(chunk.HeaderIndex, "0")
+	huge.Header.Set(chunk.HeaderCount, "2000000000")
+	_, _, err = chunk.NewAssembler(chunk.Config{}).Add(huge)
+	assert.ErrorIs(t, err, chunk.ErrTooManyChunks)
+
+	// broken headers
+	msgs[1].Header.Set(chunk.HeaderIndex, "10")
+	_, _, err = asm.Add(msgs[1])
+	assert.ErrorIs(t, err, chunk.ErrChunkHeader)
+}
@@ -0,0 +1,199 @@
+// Package consumer provides a typed wrapper around JetStream pull consumers
+// that decodes avrox messages and handles poison messages.
+//
+// Messages that can be decoded are handed to the handler. When the handler
+// succeeds the message gets acked, when it fails the message gets a nak with
+// a delay from the configured backoff. Messages that can not be decoded (unknown
+// schema, failed parity check, decompression errors...) are published to the
+// dead letter subject with diagnostic headers and terminated, so they are never
+// redelivered.
+package consumer
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/hamba/avro/v2"
+	"github.com/metatexx/avrox"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+// Headers that get added to the messages which are moved to the dead letter subject
+const (
+	HeaderError        = "Avrox-Error"
+	HeaderMagic        = "Avrox-Magic"       // N.S.V from the magic of the payload
+	HeaderCompression  = "Avrox-Compression" // compression id from the magic of the payload
+	HeaderRawMagic     = "Avrox-Raw-Magic"   // hex of the leading bytes when the magic can not be decoded
+	HeaderSubject      = "Avrox-Subject"
+	HeaderStream       = "Avrox-Stream"
+	HeaderSequence     = "Avrox-Stream-Sequence"
+	HeaderNumDelivered = "Avrox-Num-Delivered"
+)
+
+// DefaultBackoff is used when Config.Backoff is empty
+var DefaultBackoff = []time.Duration{time.Second, 5 * time.Second, 30 * time.Second, time.Minute}
+
+var (
+	ErrHandlerNil        = errors.New("consumer: handler is nil")
+	ErrUndecodable       = errors.New("consumer: message can not be decoded")
+	ErrMaxDeliveries     = errors.New("consumer: max deliveries reached")
+	ErrDeadLetterMissing = errors.New("consumer: no dead letter publisher")
+)
+
+// Publisher is used to publish dead letters (*nats.Conn implements it)
+type Publisher interface {
+	PublishMsg(msg *nats.Msg) error
+}
+
+// Handler gets called with the decoded value for every message
+type Handler[T any] func(ctx context.Context, value *T, msg jetstream.Msg) error
+
+// Config configures a Consumer
+type Config struct {
+	// DeadLetterSubject is the subject undecodable messages get published to.
+	// When it is empty, those messages only get terminated.
+	DeadLetterSubject string
+	// DeadLetter is used to publish the dead letters
+	DeadLetter Publisher
+	// Backoff holds the nak delays for the consecutive deliveries. The last
+	// entry is used for all further deliveries.
+	Backoff []time.Duration
+	// MaxDeliveries moves messages to the dead letter subject when the handler
+	// failed on the last allowed delivery (0 means unlimited).
+	MaxDeliveries uint64
+	// OnError gets called with the errors of Consume (optional)
+	OnError func(msg jetstream.Msg, err error)
+}
+
+// Consumer decodes JetStream messages into T (where *T is an avrox.Schemer)
+type Consumer[T any, PT interface {
+	*T
+	avrox.Schemer
+}] struct {
+	cfg     Config
+	handler Handler[T]
+	schema  avro.Schema
+}
+
+// New creates a consumer for the schemer type T
+func New[T any, PT interface {
+	*T
+	avrox.Schemer
+}](cfg Config, handler Handler[T]) (*Consumer[T, PT], error) {
+	if handler == nil {
+		return nil, ErrHandlerNil
+	}
+	if cfg.DeadLetterSubject != "" && cfg.DeadLetter == nil {
+		return nil, ErrDeadLetterMissing
+	}
+	if len(cfg.Backoff) == 0 {
+		cfg.Backoff = DefaultBackoff
+	}
+	var zero T
+	schema, errParse := avro.Parse(PT(&zero).Schema())
+	if errParse != nil {
+		return nil, errors.Join(avrox.ErrSchemaInvalid, errParse)
+	}
+	return &Consumer[T, PT]{cfg: cfg, handler: handler, schema: schema}, nil
+}
+
+// Consume starts consuming messages from the given JetStream consumer
+func (c *Consumer[T, PT]) Consume(ctx context.Context, cons jetstream.Consumer,
+	opts ...jetstream.PullConsumeOpt) (jetstream.ConsumeContext, error) {
+	return cons.Consume(func(msg jetstream.Msg) {
+		if err := c.Handle(ctx, msg); err != nil && c.cfg.OnError != nil {
+			c.cfg.OnError(msg, err)
+		}
+	}, opts...)
+}
+
+// Handle processes a single message. It returns an error when the message
+// could not be handled successfully (even if it was acknowledged in some way).
+func (c *Consumer[T, PT]) Handle(ctx context.Context, msg jetstream.Msg) error {
+	value := new(T)
+	errDecode := avrox.Unmarshal(msg.Data(), PT(value), c.schema)
+	if errDecode != nil {
+		errDecode = errors.Join(ErrUndecodable, errDecode)
+		return errors.Join(errDecode, c.deadLetter(msg, errDecode))
+	}
+
+	errHandler := c.handler(ctx, value, msg)
+	if errHandler == nil {
+		return msg.Ack()
+	}
+
+	delivered := c.numDelivered(msg)
+	if c.cfg.MaxDeliveries > 0 && delivered >= c.cfg.MaxDeliveries {
+		errHandler = errors.Join(ErrMaxDeliveries, errHandler)
+		return errors.Join(errHandler, c.deadLetter(msg, errHandler))
+	}
+	return errors.Join(errHandler, msg.NakWithDelay(c.backoff(delivered)))
+}
+
+// backoff returns the nak delay for the given delivery count (1 based)
+func (c *Consumer[T, PT]) backoff(delivered uint64) time.Duration {
+	if delivered == 0 {
+		delivered = 1
+	}
+	if delivered > uint64(len(c.cfg.Backoff)) {
+		return c.cfg.Backoff[len(c.cfg.Backoff)-1]
+	}
+	return c.cfg.Backoff[delivered-1]
+}
+
+func (c *Consumer[T, PT]) numDelivered(msg jetstream.Msg) uint64 {
+	meta, err := msg.Metadata()
+	if err != nil {
+		return 0
+	}
+	return meta.NumDelivered
+}
+
+// deadLetter publishes the message with diagnostic headers to the dead letter subject
+// and terminates it. If the publishing fails the message gets a nak, so it is not lost.
+func (c *Consumer[T, PT]) deadLetter(msg jetstream.Msg, reason error) error {
+	if c.cfg.DeadLetterSubject == "" {
+		return msg.Term()
+	}
+	errPublish := c.cfg.DeadLetter.PublishMsg(DeadLetterMsg(c.cfg.DeadLetterSubject, msg, reason))
+	if errPublish != nil {
+		return errors.Join(errPublish, msg.NakWithDelay(c.backoff(c.numDelivered(msg))))
+	}
+	return msg.Term()
+}
+
+// DeadLetterMsg creates the message that gets published to the dead letter subject.
+// It keeps the original data and headers and adds the diagnostic headers.
+func DeadLetterMsg(subject string, msg jetstream.Msg, reason error) *nats.Msg {
+	dl := nats.NewMsg(subject)
+	dl.Data = msg.Data()
+	for k, v := range msg.Headers() {
+		dl.Header[k] = append([]string(nil), v...)
+	}
+	dl.Header.Set(HeaderError, reason.Error())
+	dl.Header.Set(HeaderSubject, msg.Subject())
+
+	data := msg.Data()
+	if len(data) >= avrox.MagicLen {
+		nID, sID, cID, errMagic := avrox.DecodeMagic(data[:avrox.MagicLen])
+		if errMagic == nil {
+			dl.Header.Set(HeaderMagic, avrox.NSV(nID, sID))
+			dl.Header.Set(HeaderCompression, strconv.Itoa(int(cID)))
+		} else {
+			dl.Header.Set(HeaderRawMagic, hex.EncodeToString(data[:avrox.MagicLen]))
+		}
+	} else {
+		dl.Header.Set(HeaderRawMagic, hex.EncodeToString(data))
+	}
+
+	if meta, errMeta := msg.Metadata(); errMeta == nil {
+		dl.Header.Set(HeaderStream, meta.Stream)
+		dl.Header.Set(HeaderSequence, strconv.FormatUint(meta.Sequence.Stream, 10))
+		dl.Header.Set(HeaderNumDelivered, strconv.FormatUint(meta.NumDelivered, 10))
+	}
+	return dl
+}
@@ -0,0 +1,144 @@
+package consumer_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/metatexx/avrox"
+	"github.com/metatexx/avrox/nats/consumer"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeMsg records what acknowledgement was used
+type fakeMsg struct {
+	data      []byte
+	header    nats.Header
+	delivered uint64
+	acked     string
+	delay     time.Duration
+}
+
+var _ jetstream.Msg = (*fakeMsg)(nil)
+
+func (m *fakeMsg) Metadata() (*jetstream.MsgMetadata, error) {
+	return &jetstream.MsgMetadata{
+		Sequence:     jetstream.SequencePair{Stream: 42},
+		NumDelivered: m.delivered,
+		Stream:       "EVENTS",
+	}, nil
+}
+func (m *fakeMsg) Data() []byte                    { return m.data }
+func (m *fakeMsg) Headers() nats.Header            { return m.header }
+func (m *fakeMsg) Subject() string                 { return "events.test" }
+func (m *fakeMsg) Reply() string                   { return "" }
+func (m *fakeMsg) Ack() error                      { m.acked = "ack"; return nil }
+func (m *fakeMsg) DoubleAck(context.Context) error { m.acked = "ack"; return nil }
+func (m *fakeMsg) Nak() error                      { m.acked = "nak"; return nil }
+func (m *fakeMsg) InProgress() error               { return nil }
+func (m *fakeMsg) Term() error                     { m.acked = "term"; return nil }
+func (m *fakeMsg) TermWithReason(string) error     { m.acked = "term"; return nil }
+func (m *fakeMsg) NakWithDelay(delay time.Duration) error {
+	m.acked = "nak"
+	m.delay = delay
+	return nil
+}
+
+type fakePublisher struct {
+	msgs []*nats.Msg
+}
+
+func (p *fakePublisher) PublishMsg(msg *nats.Msg) error {
+	p.msgs = append(p.msgs, msg)
+	return nil
+}
+
+func TestConsumer(t *testing.T) {
+	pub := &fakePublisher{}
+	var got []string
+	handlerErr := errors.New("handler failed")
+	c, err := consumer.New[avrox.BasicString](consumer.Config{
+		DeadLetterSubject: "events.dead",
+		DeadLetter:        pub,
+		Backoff:           []time.Duration{time.Second, 2 * time.Second},
+		MaxDeliveries:     3,
+	}, func(_ context.Context, v *avrox.BasicString, _ jetstream.Msg) error {
+		got = append(got, v.Value)
+		if v.Value == "fail" {
+			return handlerErr
+		}
+		return nil
+	})
+	assert.NoError(t, err)
+
+	// success gets acked
+	data, err := avrox.Marshal(&avrox.BasicString{Value: "foo"}, avrox.CompSnappy, nil)
+	assert.NoError(t, err)
+	msg := &fakeMsg{data: data, delivered: 1}
+	assert.NoError(t, c.Handle(context.Background(), msg))
+	assert.Equal(t, "ack", msg.acked)
+	assert.Equal(t, []string{"foo"}, got)
+
+	// handler errors get a nak with backoff
+	data, err = avrox.Marshal(&avrox.BasicString{Value: "fail"}, avrox.CompNone, nil)
+	assert.NoError(t, err)
+	msg = &fakeMsg{data: data, delivered: 1}
+	assert.ErrorIs(t, c.Handle(context.Background(), msg), handlerErr)
+	assert.Equal(t, "nak", msg.acked)
+	assert.Equal(t, time.Second, msg.delay)
+
+	msg = &fakeMsg{data: data, delivered: 2}
+	assert.ErrorIs(t, c.Handle(context.Background(), msg), handlerErr)
+	assert.Equal(t, 2*time.Second, msg.delay)
+	assert.Empty(t, pub.msgs)
+
+	// last delivery moves it to the dead letter subject
+	msg = &fakeMsg{data: data, delivered: 3}
+	err = c.Handle(context.Background(), msg)
+	assert.ErrorIs(t, err, consumer.ErrMaxDeliveries)
+	assert.Equal(t, "term", msg.acked)
+	assert.Len(t, pub.msgs, 1)
+
+	// another schema is a poison message
+	data, err = avrox.MarshalBasic(42, avrox.CompNone)
+	assert.NoError(t, err)
+	msg = &fakeMsg{data: data, delivered: 1, header: nats.Header{"Foo": []string{"bar"}}}
+	err = c.Handle(context.Background(), msg)
+	assert.ErrorIs(t, err, consumer.ErrUndecodable)
+	assert.ErrorIs(t, err, avrox.ErrWrongSchema)
+	assert.Equal(t, "term", msg.acked)
+	assert.Len(t, pub.msgs, 2)
+	dl := pub.msgs[1]
+	assert.Equal(t, "events.dead", dl.Subject)
+	assert.Equal(t, data, dl.Data)
+	assert.Equal(t, "bar", dl.Header.Get("Foo"))
+	assert.Equal(t, "1.2.1", dl.Header.Get(consumer.HeaderMagic))
+	assert.Equal(t, "0", dl.Header.Get(consumer.HeaderCompression))
+	assert.Equal(t, "events.test", dl.Header.Get(consumer.HeaderSubject))
+	assert.Equal(t, "EVENTS", dl.Header.Get(consumer.HeaderStream))
+	assert.Equal(t, "42", dl.Header.Get(consumer.HeaderSequence))
+	assert.Contains(t, dl.Header.Get(consumer.HeaderError), avrox.ErrWrongSchema.Error())
+
+	// broken magic
+	data[7]++
+	msg = &fakeMsg{data: data, delivered: 1}
+	err = c.Handle(context.Background(), msg)
+	assert.ErrorIs(t, err, consumer.ErrUndecodable)
+	assert.Equal(t, "term", msg.acked)
+	assert.Len(t, pub.msgs, 3)
+	assert.Equal(t, "", pub.msgs[2].Header.Get(consumer.HeaderMagic))
+	assert.NotEmpty(t, pub.msgs[2].Header.Get(consumer.HeaderRawMagic))
+	assert.Len(t, got, 4)
+}
+
+func TestConsumerConfig(t *testing.T) {
+	_, err := consumer.New[avrox.BasicString](consumer.Config{}, nil)
+	assert.ErrorIs(t, err, consumer.ErrHandlerNil)
+
+	_, err = consumer.New[avrox.BasicString](consumer.Config{DeadLetterSubject: "dead"},
+		func(context.Context, *avrox.BasicString, jetstream.Msg) error { return nil })
+	assert.ErrorIs(t, err, consumer.ErrDeadLetterMissing)
+}
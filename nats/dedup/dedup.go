@@ -0,0 +1,59 @@
+// Package dedup sets content based message ids for the JetStream deduplication.
+//
+// The Nats-Msg-Id is computed with avrox.ContentID, so publishing the same
+// logical message twice (even with a different compression) results in the
+// same id and JetStream drops the duplicate within its duplicate window.
+// The data should be encoded with avrox.MarshalCanonical (or converted with
+// avrox.Canonical) when the schema contains maps.
+package dedup
+
+import (
+	"context"
+
+	"github.com/metatexx/avrox"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+// MsgID returns the content based message id for the avrox data
+func MsgID(data []byte) (string, error) {
+	return avrox.ContentID(data)
+}
+
+// WithContentMsgID returns the publish option which sets the content based message id
+func WithContentMsgID(data []byte) (jetstream.PublishOpt, error) {
+	id, err := MsgID(data)
+	if err != nil {
+		return nil, err
+	}
+	return jetstream.WithMsgID(id), nil
+}
+
+// SetMsgID sets the content based message id as Nats-Msg-Id header of the message
+func SetMsgID(msg *nats.Msg) error {
+	id, err := MsgID(msg.Data)
+	if err != nil {
+		return err
+	}
+	if msg.Header == nil {
+		msg.Header = nats.Header{}
+	}
+	msg.Header.Set(nats.MsgIdHdr, id)
+	return nil
+}
+
+// Publish marshals the schemer with the canonical encoding and publishes it with the
+// content based message id
+func Publish(ctx context.Context, js jetstream.JetStream, subject string, src avrox.Schemer,
+	cID avrox.CompressionID, opts ...jetstream.PublishOpt) (*jetstream.PubAck, error) {
+	data, err := avrox.MarshalCanonical(src, cID, nil)
+	if err != nil {
+		return nil, err
+	}
+	msg := nats.NewMsg(subject)
+	msg.Data = data
+	if err = SetMsgID(msg); err != nil {
+		return nil, err
+	}
+	return js.PublishMsg(ctx, msg, opts...)
+}
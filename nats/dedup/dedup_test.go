@@ -0,0 +1,34 @@
+package dedup_test
+
+import (
+	"testing"
+
+	"github.com/metatexx/avrox"
+	"github.com/metatexx/avrox/nats/dedup"
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetMsgID(t *testing.T) {
+	data, err := avrox.MarshalBasic(42, avrox.CompNone)
+	assert.NoError(t, err)
+	msg := &nats.Msg{Subject: "events", Data: data}
+	assert.NoError(t, dedup.SetMsgID(msg))
+	id, err := avrox.ContentID(data)
+	assert.NoError(t, err)
+	assert.Equal(t, id, msg.Header.Get(nats.MsgIdHdr))
+
+	data, err = avrox.MarshalBasic(42, avrox.CompGZip)
+	assert.NoError(t, err)
+	gzipID, err := dedup.MsgID(data)
+	assert.NoError(t, err)
+	assert.Equal(t, id, gzipID)
+
+	opt, err := dedup.WithContentMsgID(data)
+	assert.NoError(t, err)
+	assert.NotNil(t, opt)
+
+	msg = &nats.Msg{Subject: "events", Data: []byte("{}")}
+	assert.Error(t, dedup.SetMsgID(msg))
+	assert.Empty(t, msg.Header.Get(nats.MsgIdHdr))
+}
@@ -23,6 +23,7 @@ func init() {
 // We use an alternative cache to the default one though
 type AvroXEncoder struct {
 	Compression  avrox.CompressionID
+	Canonical    bool // uses avrox.MarshalCanonical for a deterministic encoding
 	SchmemaCache sync.Map
 }
 
@@ -51,7 +52,13 @@ func (pb *AvroXEncoder) Encode(_ string, v interface{}) ([]byte, error) {
 		pb.SchmemaCache.Store(cacheIndex, schema)
 	}
 
-	data, err := avrox.Marshal(i, pb.Compression, schema.(avro.Schema))
+	var data []byte
+	var err error
+	if pb.Canonical {
+		data, err = avrox.MarshalCanonical(i, pb.Compression, schema.(avro.Schema))
+	} else {
+		data, err = avrox.Marshal(i, pb.Compression, schema.(avro.Schema))
+	}
 	if err != nil {
 		return nil, errors.Join(ErrInvalidAvroXMsgEncode, err)
 	}
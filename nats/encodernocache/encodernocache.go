@@ -21,6 +21,7 @@ func init() {
 // We use an alternative cache to the default one though
 type AvroXEncoder struct {
 	Compression avrox.CompressionID
+	Canonical   bool // uses avrox.MarshalCanonical for a deterministic encoding
 }
 
 var (
@@ -37,7 +38,13 @@ func (pb *AvroXEncoder) Encode(_ string, v interface{}) ([]byte, error) {
 	if !found {
 		return nil, ErrInvalidAvroXMsgEncode
 	}
-	data, err := avrox.Marshal(i, pb.Compression, nil)
+	var data []byte
+	var err error
+	if pb.Canonical {
+		data, err = avrox.MarshalCanonical(i, pb.Compression, nil)
+	} else {
+		data, err = avrox.Marshal(i, pb.Compression, nil)
+	}
 	if err != nil {
 		return nil, errors.Join(ErrInvalidAvroXMsgEncode, err)
 	}
@@ -0,0 +1,269 @@
+// Package service integrates avrox with the NATS micro service framework.
+//
+// Endpoints get typed request and response bodies (avrox Schemers) and publish
+// the N.S.V and the avro schemas of both in their endpoint metadata, which is
+// part of the $SRV.INFO response. The typed Client uses the same Schemers, so
+// the contract between a service and its callers can be discovered and checked.
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hamba/avro/v2"
+	"github.com/metatexx/avrox"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/micro"
+)
+
+// Metadata keys for the endpoint schemas
+const (
+	MetaRequest        = "avrox_request"         // N.S.V of the request
+	MetaRequestSchema  = "avrox_request_schema"  // avsc of the request
+	MetaResponse       = "avrox_response"        // N.S.V of the response
+	MetaResponseSchema = "avrox_response_schema" // avsc of the response
+)
+
+// Error codes that are used for the micro error responses
+const (
+	CodeBadRequest = "400"
+	CodeInternal   = "500"
+)
+
+var (
+	ErrNoEndpoint       = errors.New("service: endpoint not found")
+	ErrContractMismatch = errors.New("service: endpoint schemas do not match")
+)
+
+func init() {
+	avrox.RegisterErrorCode("service.ErrNoEndpoint", ErrNoEndpoint)
+	avrox.RegisterErrorCode("service.ErrContractMismatch", ErrContractMismatch)
+}
+
+// Error can be returned by a handler to control the error code of the response.
+// The client returns it for error responses.
+type Error struct {
+	Code        string
+	Description string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("service error %s: %s", e.Code, e.Description)
+}
+
+// Handler gets called with the decoded request and returns the response. The ctx is
+// derived from the service context of NewHandler and gets cancelled when the handler returns.
+type Handler[Req, Resp any] func(ctx context.Context, value *Req, req micro.Request) (*Resp, error)
+
+// EndpointAdder is implemented by micro.Service and micro.Group
+type EndpointAdder interface {
+	AddEndpoint(name string, handler micro.Handler, opts ...micro.EndpointOpt) error
+}
+
+// EndpointSchema is the contract of an endpoint as published in its metadata
+type EndpointSchema struct {
+	Request        string // N.S.V
+	RequestSchema  string
+	Response       string // N.S.V
+	ResponseSchema string
+}
+
+// EndpointMetadata returns the endpoint metadata for the request and response schemer.
+// Entries from extra are copied into the result (the schema entries win).
+func EndpointMetadata(req, resp avrox.Schemer, extra map[string]string) map[string]string {
+	meta := make(map[string]string, len(extra)+4)
+	for k, v := range extra {
+		meta[k] = v
+	}
+	meta[MetaRequest] = avrox.NSV(req.NamespaceID(), req.SchemaID())
+	meta[MetaRequestSchema] = req.Schema()
+	meta[MetaResponse] = avrox.NSV(resp.NamespaceID(), resp.SchemaID())
+	meta[MetaResponseSchema] = resp.Schema()
+	return meta
+}
+
+// Schemas returns the contract that is published in the metadata of the endpoint
+func Schemas(ep micro.EndpointInfo) (EndpointSchema, bool) {
+	es := EndpointSchema{
+		Request:        ep.Metadata[MetaRequest],
+		RequestSchema:  ep.Metadata[MetaRequestSchema],
+		Response:       ep.Metadata[MetaResponse],
+		ResponseSchema: ep.Metadata[MetaResponseSchema],
+	}
+	return es, es.Request != "" && es.Response != ""
+}
+
+// AddEndpoint adds a typed endpoint to the service or group. The metadata of the
+// endpoint contains the schemas of the request and response types merged with extra
+// (see EndpointMetadata). It replaces a micro.WithEndpointMetadata in opts, so
+// additional metadata has to be given as extra.
+// The response uses the same compression as the request. See NewHandler for serviceCtx.
+func AddEndpoint[Req, Resp any, PReq interface {
+	*Req
+	avrox.Schemer
+}, PResp interface {
+	*Resp
+	avrox.Schemer
+}](serviceCtx context.Context, adder EndpointAdder, name string, handler Handler[Req, Resp],
+	extra map[string]string, opts ...micro.EndpointOpt,
+) error {
+	h, err := NewHandler[Req, Resp, PReq, PResp](serviceCtx, handler)
+	if err != nil {
+		return err
+	}
+	var req Req
+	var resp Resp
+	meta := micro.WithEndpointMetadata(EndpointMetadata(PReq(&req), PResp(&resp), extra))
+	return adder.AddEndpoint(name, h, append(opts[:len(opts):len(opts)], meta)...)
+}
+
+// NewHandler creates the micro.Handler which decodes the request, calls the handler
+// and encodes the response. The serviceCtx is the lifetime context of the service
+// (not of a single request): every request gets a context derived from it, which is
+// cancelled when its handler returns. Once serviceCtx is cancelled, the handlers of
+// all later requests get a cancelled context. Deadlines per request can be set by
+// the handler itself.
+func NewHandler[Req, Resp any, PReq interface {
+	*Req
+	avrox.Schemer
+}, PResp interface {
+	*Resp
+	avrox.Schemer
+}](serviceCtx context.Context, handler Handler[Req, Resp]) (micro.Handler, error) {
+	var req Req
+	var resp Resp
+	reqSchema, errReq := avro.Parse(PReq(&req).Schema())
+	if errReq != nil {
+		return nil, errors.Join(avrox.ErrSchemaInvalid, errReq)
+	}
+	respSchema, errResp := avro.Parse(PResp(&resp).Schema())
+	if errResp != nil {
+		return nil, errors.Join(avrox.ErrSchemaInvalid, errResp)
+	}
+
+	return micro.HandlerFunc(func(r micro.Request) {
+		value := new(Req)
+		if err := avrox.Unmarshal(r.Data(), PReq(value), reqSchema); err != nil {
+			_ = r.Error(CodeBadRequest, err.Error(), nil)
+			return
+		}
+		ctx, cancel := context.WithCancel(serviceCtx)
+		result, err := handler(ctx, value, r)
+		cancel()
+		if err != nil {
+			var se *Error
+			if errors.As(err, &se) {
+				_ = r.Error(se.Code, se.Description, nil)
+			} else {
+				_ = r.Error(CodeInternal, err.Error(), nil)
+			}
+			return
+		}
+		if result == nil {
+			result = new(Resp)
+		}
+		cID := avrox.CompNone
+		if len(r.Data()) >= avrox.MagicLen {
+			_, _, cID, _ = avrox.DecodeMagic(r.Data()[:avrox.MagicLen])
+		}
+		data, err := avrox.Marshal(PResp(result), cID, respSchema)
+		if err != nil {
+			_ = r.Error(CodeInternal, err.Error(), nil)
+			return
+		}
+		_ = r.Respond(data)
+	}), nil
+}
+
+// Client is a typed client for an endpoint that was added with AddEndpoint
+type Client[Req, Resp any, PReq interface {
+	*Req
+	avrox.Schemer
+}, PResp interface {
+	*Resp
+	avrox.Schemer
+}] struct {
+	Compression avrox.CompressionID
+	nc          *nats.Conn
+	subject     string
+	reqSchema   avro.Schema
+	respSchema  avro.Schema
+}
+
+// NewClient creates a typed client for the endpoint with the given subject
+func NewClient[Req, Resp any, PReq interface {
+	*Req
+	avrox.Schemer
+}, PResp interface {
+	*Resp
+	avrox.Schemer
+}](nc *nats.Conn, subject string) (*Client[Req, Resp, PReq, PResp], error) {
+	var req Req
+	var resp Resp
+	reqSchema, errReq := avro.Parse(PReq(&req).Schema())
+	if errReq != nil {
+		return nil, errors.Join(avrox.ErrSchemaInvalid, errReq)
+	}
+	respSchema, errResp := avro.Parse(PResp(&resp).Schema())
+	if errResp != nil {
+		return nil, errors.Join(avrox.ErrSchemaInvalid, errResp)
+	}
+	return &Client[Req, Resp, PReq, PResp]{
+		nc:         nc,
+		subject:    subject,
+		reqSchema:  reqSchema,
+		respSchema: respSchema,
+	}, nil
+}
+
+// Request sends the request to the endpoint and decodes the response.
+// Error responses of the service are returned as *Error.
+func (c *Client[Req, Resp, PReq, PResp]) Request(ctx context.Context, value *Req) (*Resp, error) {
+	data, err := avrox.Marshal(PReq(value), c.Compression, c.reqSchema)
+	if err != nil {
+		return nil, err
+	}
+	msg, err := c.nc.RequestWithContext(ctx, c.subject, data)
+	if err != nil {
+		return nil, err
+	}
+	if code := msg.Header.Get(micro.ErrorCodeHeader); code != "" {
+		return nil, &Error{Code: code, Description: msg.Header.Get(micro.ErrorHeader)}
+	}
+	result := new(Resp)
+	return result, avrox.Unmarshal(msg.Data, PResp(result), c.respSchema)
+}
+
+// Verify checks that the service has an endpoint for the subject of the client
+// which publishes the same schemas as the client uses
+func (c *Client[Req, Resp, PReq, PResp]) Verify(info micro.Info) error {
+	for _, ep := range info.Endpoints {
+		if ep.Subject != c.subject {
+			continue
+		}
+		es, ok := Schemas(ep)
+		if !ok {
+			return ErrContractMismatch
+		}
+		var req Req
+		var resp Resp
+		if es.Request != avrox.NSV(PReq(&req).NamespaceID(), PReq(&req).SchemaID()) ||
+			es.Response != avrox.NSV(PResp(&resp).NamespaceID(), PResp(&resp).SchemaID()) {
+			return ErrContractMismatch
+		}
+		if !sameSchema(es.RequestSchema, c.reqSchema) || !sameSchema(es.ResponseSchema, c.respSchema) {
+			return ErrContractMismatch
+		}
+		return nil
+	}
+	return ErrNoEndpoint
+}
+
+func sameSchema(avsc string, schema avro.Schema) bool {
+	published, err := avro.Parse(avsc)
+	if err != nil {
+		return false
+	}
+	return published.Fingerprint() == schema.Fingerprint()
+}
@@ -0,0 +1,134 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/metatexx/avrox"
+	"github.com/metatexx/avrox/nats/service"
+	"github.com/nats-io/nats.go/micro"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeRequest records the response of the handler
+type fakeRequest struct {
+	data     []byte
+	response []byte
+	code     string
+	desc     string
+}
+
+var _ micro.Request = (*fakeRequest)(nil)
+
+func (r *fakeRequest) Respond(data []byte, _ ...micro.RespondOpt) error {
+	r.response = data
+	return nil
+}
+func (r *fakeRequest) RespondJSON(any, ...micro.RespondOpt) error { return nil }
+func (r *fakeRequest) Error(code, description string, _ []byte, _ ...micro.RespondOpt) error {
+	r.code = code
+	r.desc = description
+	return nil
+}
+func (r *fakeRequest) Data() []byte           { return r.data }
+func (r *fakeRequest) Headers() micro.Headers { return nil }
+func (r *fakeRequest) Subject() string        { return "svc.len" }
+
+func TestHandler(t *testing.T) {
+	var requestCtx context.Context
+	h, err := service.NewHandler[avrox.BasicString, avrox.BasicInt](context.Background(),
+		func(ctx context.Context, v *avrox.BasicString, _ micro.Request) (*avrox.BasicInt, error) {
+			requestCtx = ctx
+			if v.Value == "" {
+				return nil, &service.Error{Code: "422", Description: "empty"}
+			}
+			if v.Value == "fail" {
+				return nil, errors.New("failed")
+			}
+			return &avrox.BasicInt{Value: len(v.Value)}, nil
+		})
+	assert.NoError(t, err)
+
+	data, err := avrox.Marshal(&avrox.BasicString{Value: "foobar"}, avrox.CompSnappy, nil)
+	assert.NoError(t, err)
+	req := &fakeRequest{data: data}
+	h.Handle(req)
+	assert.Empty(t, req.code)
+	_, _, c, err := avrox.DecodeMagic(req.response[:avrox.MagicLen])
+	assert.NoError(t, err)
+	assert.Equal(t, avrox.CompSnappy, c)
+	resp := &avrox.BasicInt{}
+	assert.NoError(t, avrox.Unmarshal(req.response, resp, nil))
+	assert.Equal(t, 6, resp.Value)
+	// every request gets its own context which ends with the handler
+	assert.ErrorIs(t, requestCtx.Err(), context.Canceled)
+
+	data, err = avrox.Marshal(&avrox.BasicString{Value: ""}, avrox.CompNone, nil)
+	assert.NoError(t, err)
+	req = &fakeRequest{data: data}
+	h.Handle(req)
+	assert.Equal(t, "422", req.code)
+	assert.Equal(t, "empty", req.desc)
+
+	data, err = avrox.Marshal(&avrox.BasicString{Value: "fail"}, avrox.CompNone, nil)
+	assert.NoError(t, err)
+	req = &fakeRequest{data: data}
+	h.Handle(req)
+	assert.Equal(t, service.CodeInternal, req.code)
+
+	data, err = avrox.MarshalBasic(42, avrox.CompNone)
+	assert.NoError(t, err)
+	req = &fakeRequest{data: data}
+	h.Handle(req)
+	assert.Equal(t, service.CodeBadRequest, req.code)
+	assert.Nil(t, req.response)
+}
+
+// fakeAdder records the options of the endpoint
+type fakeAdder struct {
+	opts []micro.EndpointOpt
+}
+
+func (a *fakeAdder) AddEndpoint(_ string, _ micro.Handler, opts ...micro.EndpointOpt) error {
+	a.opts = opts
+	return nil
+}
+
+func TestAddEndpoint(t *testing.T) {
+	adder := &fakeAdder{}
+	err := service.AddEndpoint[avrox.BasicString, avrox.BasicInt](context.Background(), adder, "len",
+		func(_ context.Context, v *avrox.BasicString, _ micro.Request) (*avrox.BasicInt, error) {
+			return &avrox.BasicInt{Value: len(v.Value)}, nil
+		},
+		map[string]string{"foo": "bar"}, micro.WithEndpointQueueGroup("workers"))
+	assert.NoError(t, err)
+	// the options of the caller are kept and the metadata is added
+	assert.Len(t, adder.opts, 2)
+}
+
+func TestContract(t *testing.T) {
+	meta := service.EndpointMetadata(&avrox.BasicString{}, &avrox.BasicInt{}, map[string]string{"foo": "bar"})
+	assert.Equal(t, "bar", meta["foo"])
+	assert.Equal(t, "1.1.1", meta[service.MetaRequest])
+	assert.Equal(t, "1.2.1", meta[service.MetaResponse])
+	assert.Equal(t, avrox.BasicStringAVSC, meta[service.MetaRequestSchema])
+	assert.Equal(t, avrox.BasicIntAVSC, meta[service.MetaResponseSchema])
+
+	info := micro.Info{Endpoints: []micro.EndpointInfo{{Name: "len", Subject: "svc.len", Metadata: meta}}}
+	es, ok := service.Schemas(info.Endpoints[0])
+	assert.True(t, ok)
+	assert.Equal(t, "1.1.1", es.Request)
+
+	c, err := service.NewClient[avrox.BasicString, avrox.BasicInt](nil, "svc.len")
+	assert.NoError(t, err)
+	assert.NoError(t, c.Verify(info))
+
+	other, err := service.NewClient[avrox.BasicString, avrox.BasicString](nil, "svc.len")
+	assert.NoError(t, err)
+	assert.ErrorIs(t, other.Verify(info), service.ErrContractMismatch)
+
+	missing, err := service.NewClient[avrox.BasicString, avrox.BasicInt](nil, "svc.other")
+	assert.NoError(t, err)
+	assert.ErrorIs(t, missing.Verify(info), service.ErrNoEndpoint)
+}
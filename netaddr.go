@@ -0,0 +1,65 @@
+package avrox
+
+import (
+	"net/netip"
+)
+
+// IPAddr is the avro representation of a netip.Addr. An IPv4 address is stored as
+// 4 fixed bytes and an IPv6 address as 16 fixed bytes (with its zone). Both are nil
+// for the zero (invalid) address. It can be used as field in own records with the schema:
+//
+//	{"type":"record","name":"IPAddr","fields":[
+//	  {"name":"V4","type":["null",{"type":"fixed","name":"IPv4_4","size":4}]},
+//	  {"name":"V6","type":["null",{"type":"fixed","name":"IPv6_16","size":16}]},
+//	  {"name":"Zone","type":"string"}]}
+type IPAddr struct {
+	V4   *[4]byte
+	V6   *[16]byte
+	Zone string
+}
+
+// NewIPAddr converts the netip.Addr
+func NewIPAddr(addr netip.Addr) IPAddr {
+	switch {
+	case addr.Is4():
+		b := addr.As4()
+		return IPAddr{V4: &b}
+	case addr.Is6():
+		b := addr.As16()
+		return IPAddr{V6: &b, Zone: addr.Zone()}
+	default:
+		return IPAddr{}
+	}
+}
+
+// Addr returns the netip.Addr
+func (a IPAddr) Addr() netip.Addr {
+	switch {
+	case a.V4 != nil:
+		return netip.AddrFrom4(*a.V4)
+	case a.V6 != nil:
+		return netip.AddrFrom16(*a.V6).WithZone(a.Zone)
+	default:
+		return netip.Addr{}
+	}
+}
+
+// IPPrefix is the avro representation of a netip.Prefix (the address and its prefix length).
+// Bits is -1 for the zero (invalid) prefix.
+type IPPrefix struct {
+	Addr IPAddr
+	Bits int
+}
+
+// NewIPPrefix converts the netip.Prefix
+func NewIPPrefix(prefix netip.Prefix) IPPrefix {
+	return IPPrefix{Addr: NewIPAddr(prefix.Addr()), Bits: prefix.Bits()}
+}
+
+// Prefix returns the netip.Prefix
+func (p IPPrefix) Prefix() netip.Prefix {
+	if p.Bits < 0 {
+		return netip.Prefix{}
+	}
+	return netip.PrefixFrom(p.Addr.Addr(), p.Bits)
+}
@@ -0,0 +1,145 @@
+package rawdate
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrNoBusinessDay is returned when there is no business day within a year (like when
+// all weekdays are Weekend days)
+var ErrNoBusinessDay = errors.New("no business day within a year")
+
+// maxBusinessDaySearch is the number of days searched for the next business day
+const maxBusinessDaySearch = 366
+
+// Calendar knows which dates are business days
+type Calendar interface {
+	// IsBusinessDay reports whether the date is a business day
+	IsBusinessDay(d RawDate) bool
+	// AddBusinessDays moves n business days forward (or backward when n is negative)
+	AddBusinessDays(d RawDate, n int) (RawDate, error)
+	// BusinessDaysBetween returns the number of business days after a up to b
+	BusinessDaysBetween(a, b RawDate) int
+	// NextBusinessDay returns the next business day after d (or d itself with orToday)
+	NextBusinessDay(d RawDate, orToday bool) (RawDate, error)
+}
+
+var _ Calendar = (*BusinessCalendar)(nil)
+
+// BusinessCalendar is a Calendar where all days except the Weekend days and the holidays
+// of the Holidays provider are business days. The holidays are cached, so the fields
+// should not be changed after the calendar got used. It is safe for concurrent use.
+//
+// The provider years before and after the year of a date are loaded as well, so holidays
+// which the rules move into another year (with an Offset) are found.
+type BusinessCalendar struct {
+	Weekend  []time.Weekday
+	Holidays HolidayProvider
+
+	mu       sync.Mutex
+	loaded   map[int]bool
+	holidays map[Days]string
+}
+
+// NewBusinessCalendar creates a calendar with Saturday and Sunday as weekend and the
+// holidays of the provider (which may be nil).
+func NewBusinessCalendar(holidays HolidayProvider) *BusinessCalendar {
+	return &BusinessCalendar{Weekend: []time.Weekday{time.Saturday, time.Sunday}, Holidays: holidays}
+}
+
+// Holiday returns the name of the holiday on the date
+func (c *BusinessCalendar) Holiday(d RawDate) (string, bool) {
+	if c.Holidays == nil {
+		return "", false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.loaded == nil {
+		c.loaded = map[int]bool{}
+		c.holidays = map[Days]string{}
+	}
+	for year := d.Year() - 1; year <= d.Year()+1; year++ {
+		if c.loaded[year] {
+			continue
+		}
+		for _, h := range c.Holidays.Holidays(year) {
+			if _, dup := c.holidays[h.Date.Days()]; !dup {
+				c.holidays[h.Date.Days()] = h.Name
+			}
+		}
+		c.loaded[year] = true
+	}
+	name, found := c.holidays[d.Days()]
+	return name, found
+}
+
+// IsWeekend reports whether the date is on one of the Weekend days
+func (c *BusinessCalendar) IsWeekend(d RawDate) bool {
+	wd := d.Weekday()
+	for _, w := range c.Weekend {
+		if w == wd {
+			return true
+		}
+	}
+	return false
+}
+
+// IsBusinessDay reports whether the date is neither on the weekend nor a holiday
+func (c *BusinessCalendar) IsBusinessDay(d RawDate) bool {
+	if c.IsWeekend(d) {
+		return false
+	}
+	_, holiday := c.Holiday(d)
+	return !holiday
+}
+
+// AddBusinessDays moves n business days forward (or backward when n is negative). The
+// date itself is not counted, so adding 1 to a Friday returns the Monday (without holidays).
+// Adding 0 returns the date even when it is no business day. It returns ErrNoBusinessDay
+// when there is no business day within a year of a step.
+func (c *BusinessCalendar) AddBusinessDays(d RawDate, n int) (RawDate, error) {
+	step := 1
+	if n < 0 {
+		step, n = -1, -n
+	}
+	days := d.Days()
+	for n > 0 {
+		found := false
+		for i := 0; i < maxBusinessDaySearch && !found; i++ {
+			days += Days(step)
+			found = c.IsBusinessDay(FromDays(days))
+		}
+		if !found {
+			return d, ErrNoBusinessDay
+		}
+		n--
+	}
+	return FromDays(days), nil
+}
+
+// BusinessDaysBetween returns the number of business days after a up to b (including b).
+// When b is before a, it is the negated number of business days from b up to a (excluding a).
+// So for a business day b, AddBusinessDays(a, BusinessDaysBetween(a, b)) == b.
+func (c *BusinessCalendar) BusinessDaysBetween(a, b RawDate) int {
+	from, to, sign := a.Days()+1, b.Days(), 1
+	if b.Before(a) {
+		from, to, sign = b.Days(), a.Days()-1, -1
+	}
+	count := 0
+	for days := from; days <= to; days++ {
+		if c.IsBusinessDay(FromDays(days)) {
+			count++
+		}
+	}
+	return sign * count
+}
+
+// NextBusinessDay returns the next business day after d.
+// If orToday is true and d is a business day, it returns d.
+func (c *BusinessCalendar) NextBusinessDay(d RawDate, orToday bool) (RawDate, error) {
+	if orToday && c.IsBusinessDay(d) {
+		return d, nil
+	}
+	return c.AddBusinessDays(d, 1)
+}
@@ -0,0 +1,200 @@
+package rawdate_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/metatexx/avrox/rawdate"
+)
+
+func TestEaster(t *testing.T) {
+	tests := []struct {
+		year int
+		want rawdate.RawDate
+	}{
+		{1961, ymd(1961, 4, 2)},
+		{2000, ymd(2000, 4, 23)},
+		{2008, ymd(2008, 3, 23)},
+		{2019, ymd(2019, 4, 21)},
+		{2024, ymd(2024, 3, 31)},
+		{2025, ymd(2025, 4, 20)},
+		{2038, ymd(2038, 4, 25)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want.String(), func(t *testing.T) {
+			assert.Equal(t, tt.want, rawdate.Easter(tt.year))
+		})
+	}
+}
+
+func TestHolidayRule_Date(t *testing.T) {
+	tests := []struct {
+		name string
+		rule rawdate.HolidayRule
+		year int
+		want rawdate.RawDate
+		ok   bool
+	}{
+		{"fixed", rawdate.HolidayRule{Kind: rawdate.RuleFixed, Month: time.October, Day: 3}, 2024, ymd(2024, 10, 3), true},
+		{"fixed leap day", rawdate.HolidayRule{Kind: rawdate.RuleFixed, Month: time.February, Day: 29}, 2023, rawdate.Zero, false},
+		{"easter offset", rawdate.HolidayRule{Kind: rawdate.RuleEaster, Offset: 39}, 2024, ymd(2024, 5, 9), true},
+		{"first monday", rawdate.HolidayRule{Kind: rawdate.RuleNthWeekday, Month: time.September, Weekday: time.Monday, N: 1}, 2024, ymd(2024, 9, 2), true},
+		{"fourth thursday", rawdate.HolidayRule{Kind: rawdate.RuleNthWeekday, Month: time.November, Weekday: time.Thursday, N: 4}, 2024, ymd(2024, 11, 28), true},
+		{"last monday", rawdate.HolidayRule{Kind: rawdate.RuleNthWeekday, Month: time.May, Weekday: time.Monday, N: -1}, 2024, ymd(2024, 5, 27), true},
+		{"second to last friday", rawdate.HolidayRule{Kind: rawdate.RuleNthWeekday, Month: time.May, Weekday: time.Friday, N: -2}, 2024, ymd(2024, 5, 24), true},
+		{"fifth friday missing", rawdate.HolidayRule{Kind: rawdate.RuleNthWeekday, Month: time.February, Weekday: time.Friday, N: 5}, 2024, rawdate.Zero, false},
+		{"weekday before day", rawdate.HolidayRule{Kind: rawdate.RuleNthWeekday, Month: time.November, Day: 22, Weekday: time.Wednesday, N: -1}, 2023, ymd(2023, 11, 22), true},
+		{"weekday after day with offset", rawdate.HolidayRule{Kind: rawdate.RuleNthWeekday, Month: time.October, Day: 1, Weekday: time.Sunday, N: 1, Offset: 1}, 2024, ymd(2024, 10, 7), true},
+		{"before from year", rawdate.HolidayRule{Kind: rawdate.RuleFixed, Month: time.March, Day: 8, FromYear: 2019}, 2018, rawdate.Zero, false},
+		{"after to year", rawdate.HolidayRule{Kind: rawdate.RuleFixed, Month: time.May, Day: 8, FromYear: 2020, ToYear: 2020}, 2021, rawdate.Zero, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.NoError(t, tt.rule.Validate())
+			date, ok := tt.rule.Date(tt.year)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.want, date)
+		})
+	}
+}
+
+func TestGermanHolidays(t *testing.T) {
+	tests := []struct {
+		state rawdate.GermanState
+		year  int
+		count int
+		has   []rawdate.RawDate
+		not   []rawdate.RawDate
+	}{
+		{state: "", year: 2024, count: 9, has: []rawdate.RawDate{ymd(2024, 3, 29), ymd(2024, 10, 3)}, not: []rawdate.RawDate{ymd(2024, 1, 6)}},
+		{state: rawdate.StateBY, year: 2024, count: 12, has: []rawdate.RawDate{ymd(2024, 1, 6), ymd(2024, 5, 30), ymd(2024, 11, 1)}, not: []rawdate.RawDate{ymd(2024, 10, 31)}},
+		{state: rawdate.StateBE, year: 2024, count: 10, has: []rawdate.RawDate{ymd(2024, 3, 8)}},
+		{state: rawdate.StateBE, year: 2025, count: 11, has: []rawdate.RawDate{ymd(2025, 5, 8)}},
+		{state: rawdate.StateSN, year: 2024, count: 11, has: []rawdate.RawDate{ymd(2024, 10, 31), ymd(2024, 11, 20)}},
+		{state: rawdate.StateNI, year: 2017, count: 10, has: []rawdate.RawDate{ymd(2017, 10, 31)}},
+		{state: rawdate.StateHE, year: 2017, count: 11, has: []rawdate.RawDate{ymd(2017, 10, 31), ymd(2017, 6, 15)}},
+		{state: rawdate.StateHE, year: 2018, count: 10, not: []rawdate.RawDate{ymd(2018, 10, 31)}},
+		{state: rawdate.StateTH, year: 2024, count: 11, has: []rawdate.RawDate{ymd(2024, 9, 20)}},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.state)+"-"+ymd(tt.year, 1, 1).Format("2006"), func(t *testing.T) {
+			rules, err := rawdate.GermanHolidays(tt.state)
+			assert.NoError(t, err)
+			assert.NoError(t, rules.Validate())
+			holidays := rules.Holidays(tt.year)
+			assert.Len(t, holidays, tt.count)
+			cal := rawdate.NewBusinessCalendar(rules)
+			for _, d := range tt.has {
+				_, found := cal.Holiday(d)
+				assert.True(t, found, d.String())
+			}
+			for _, d := range tt.not {
+				_, found := cal.Holiday(d)
+				assert.False(t, found, d.String())
+			}
+		})
+	}
+	for _, state := range rawdate.GermanStates {
+		_, err := rawdate.GermanHolidays(state)
+		assert.NoError(t, err)
+	}
+	_, err := rawdate.GermanHolidays("XX")
+	assert.ErrorIs(t, err, rawdate.ErrUnknownState)
+}
+
+func TestBusinessCalendar(t *testing.T) {
+	rules, err := rawdate.GermanHolidays(rawdate.StateNW)
+	assert.NoError(t, err)
+	var cal rawdate.Calendar = rawdate.NewBusinessCalendar(rules)
+
+	tests := []struct {
+		name  string
+		start rawdate.RawDate
+		n     int
+		want  rawdate.RawDate
+	}{
+		{"zero", ymd(2024, 3, 30), 0, ymd(2024, 3, 30)},
+		{"next day", ymd(2024, 3, 5), 1, ymd(2024, 3, 6)},
+		{"over weekend", ymd(2024, 3, 8), 1, ymd(2024, 3, 11)},
+		{"over easter", ymd(2024, 3, 28), 1, ymd(2024, 4, 2)},
+		{"five days", ymd(2024, 12, 20), 5, ymd(2024, 12, 31)},
+		{"backwards over easter", ymd(2024, 4, 2), -1, ymd(2024, 3, 28)},
+		{"backwards from weekend", ymd(2024, 3, 10), -2, ymd(2024, 3, 7)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := cal.AddBusinessDays(tt.start, tt.n)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.n, cal.BusinessDaysBetween(tt.start, got))
+		})
+	}
+
+	assert.False(t, cal.IsBusinessDay(ymd(2024, 5, 30))) // Fronleichnam
+	assert.True(t, cal.IsBusinessDay(ymd(2024, 5, 31)))
+	next, err := cal.NextBusinessDay(ymd(2024, 3, 29), true)
+	assert.NoError(t, err)
+	assert.Equal(t, ymd(2024, 4, 2), next)
+	next, err = cal.NextBusinessDay(ymd(2024, 4, 2), false)
+	assert.NoError(t, err)
+	assert.Equal(t, ymd(2024, 4, 3), next)
+	next, err = cal.NextBusinessDay(ymd(2024, 4, 2), true)
+	assert.NoError(t, err)
+	assert.Equal(t, ymd(2024, 4, 2), next)
+	assert.Equal(t, 19, cal.BusinessDaysBetween(ymd(2024, 4, 30), ymd(2024, 5, 31))) // May 2024 in NW
+	assert.Equal(t, 0, cal.BusinessDaysBetween(ymd(2024, 3, 29), ymd(2024, 4, 1)))
+
+	// custom week without holidays
+	sixDays := &rawdate.BusinessCalendar{Weekend: []time.Weekday{time.Sunday}}
+	next, err = sixDays.AddBusinessDays(ymd(2024, 3, 8), 1)
+	assert.NoError(t, err)
+	assert.Equal(t, ymd(2024, 3, 9), next)
+
+	// holidays which the offset moves into the next year
+	newYear := rawdate.NewBusinessCalendar(rawdate.HolidayRules{
+		{Name: "Tag nach Silvester", Kind: rawdate.RuleFixed, Month: time.December, Day: 31, Offset: 1},
+	})
+	assert.False(t, newYear.IsBusinessDay(ymd(2025, 1, 1)))
+	assert.True(t, newYear.IsBusinessDay(ymd(2024, 12, 31)))
+
+	// calendars without business days do not loop forever
+	never := &rawdate.BusinessCalendar{Weekend: []time.Weekday{
+		time.Sunday, time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday,
+	}}
+	_, err = never.AddBusinessDays(ymd(2024, 3, 8), -1)
+	assert.ErrorIs(t, err, rawdate.ErrNoBusinessDay)
+	_, err = never.NextBusinessDay(ymd(2024, 3, 8), true)
+	assert.ErrorIs(t, err, rawdate.ErrNoBusinessDay)
+}
+
+func TestLoadHolidayRules(t *testing.T) {
+	custom, err := rawdate.LoadHolidayRules("testdata/holidays.json")
+	assert.NoError(t, err)
+	assert.Len(t, custom, 4)
+	assert.Equal(t, []rawdate.Holiday{
+		{Date: ymd(2024, 2, 12), Name: "Rosenmontag"},
+		{Date: ymd(2024, 6, 28), Name: "Betriebsausflug"},
+		{Date: ymd(2024, 12, 24), Name: "Heiligabend"},
+		{Date: ymd(2024, 12, 31), Name: "Silvester"},
+	}, custom.Holidays(2024))
+	assert.Len(t, custom.Holidays(2023), 3)
+
+	rules, err := rawdate.GermanHolidays(rawdate.StateNW)
+	assert.NoError(t, err)
+	cal := rawdate.NewBusinessCalendar(append(rules, custom...))
+	end, err := cal.AddBusinessDays(ymd(2024, 12, 20), 5)
+	assert.NoError(t, err)
+	assert.Equal(t, ymd(2025, 1, 3), end)
+
+	_, err = rawdate.LoadHolidayRules("testdata/holidays_invalid.json")
+	assert.ErrorIs(t, err, rawdate.ErrHolidayRule)
+	_, err = rawdate.ReadHolidayRules(strings.NewReader(`[{"name":"x","kind":"fixed","month":2,"date":30}]`))
+	assert.ErrorIs(t, err, rawdate.ErrHolidayRule)
+	_, err = rawdate.ReadHolidayRules(strings.NewReader(`[{"name":"x","kind":"easter","offset":400}]`))
+	assert.ErrorIs(t, err, rawdate.ErrHolidayRule)
+	_, err = rawdate.LoadHolidayRules("testdata/missing.json")
+	assert.Error(t, err)
+}
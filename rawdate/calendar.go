@@ -0,0 +1,130 @@
+package rawdate
+
+import (
+	"errors"
+	"time"
+)
+
+// The calendar arithmetic below works on the zero-based fields (proleptic gregorian
+// calendar) and does not create time.Time values.
+
+// ErrISOWeek is returned by FromISOWeek for a week which the year does not have
+var ErrISOWeek = errors.New("not an iso week of the year")
+
+// IsLeapYear reports whether the year has a February 29.
+func IsLeapYear(year int) bool {
+	return year%4 == 0 && (year%100 != 0 || year%400 == 0)
+}
+
+// DaysIn returns the number of days of the month in the year.
+func DaysIn(year int, month time.Month) int {
+	switch month {
+	case time.February:
+		if IsLeapYear(year) {
+			return 29
+		}
+		return 28
+	case time.April, time.June, time.September, time.November:
+		return 30
+	default:
+		return 31
+	}
+}
+
+// DaysBetween returns the number of days from a to b (negative if b is before a).
+func DaysBetween(a, b RawDate) int {
+	return int(b.Days() - a.Days())
+}
+
+// MonthsBetween returns the number of complete months from a to b. A month is complete
+// when b reaches the day of the month of a. If that day does not exist in the month of b,
+// the last day of that month completes it (from January 31 it is one month to February 29
+// and to March 30, but two months to March 31). When b is before a, the result is the
+// negated number of months from b to a.
+func MonthsBetween(a, b RawDate) int {
+	if b.Before(a) {
+		return -MonthsBetween(b, a)
+	}
+	months := (b.Year0-a.Year0)*12 + int(b.Month0-a.Month0)
+	if b.Day0 < a.Day0 && b.Day() != DaysIn(b.Year(), b.Month()) {
+		months--
+	}
+	return months
+}
+
+// Age returns the number of complete years from the RawDate (like a birthday) to the
+// date on. It follows the end-of-month rule of MonthsBetween, so someone born on February
+// 29 gets a year older on February 28 in other years. It is negative if on is before r.
+func (r RawDate) Age(on RawDate) int {
+	return MonthsBetween(r, on) / 12
+}
+
+// DayOfYear returns the day of the year (1 to 365 or 366 in leap years).
+func (r RawDate) DayOfYear() int {
+	return int(r.Days()-r.YearStart().Days()) + 1
+}
+
+// YearStart returns January 1 of the year of the RawDate.
+func (r RawDate) YearStart() RawDate {
+	return RawDate{Year0: r.Year0}
+}
+
+// YearEnd returns December 31 of the year of the RawDate.
+func (r RawDate) YearEnd() RawDate {
+	return RawDate{Year0: r.Year0, Month0: 11, Day0: 30}
+}
+
+// Quarter returns the quarter of the year (1 to 4).
+func (r RawDate) Quarter() int {
+	return int(r.Month0)/3 + 1
+}
+
+// QuarterStart returns the first day of the quarter of the RawDate.
+func (r RawDate) QuarterStart() RawDate {
+	return RawDate{Year0: r.Year0, Month0: r.Month0 / 3 * 3}
+}
+
+// QuarterEnd returns the last day of the quarter of the RawDate.
+func (r RawDate) QuarterEnd() RawDate {
+	month0 := r.Month0/3*3 + 2
+	return RawDate{Year0: r.Year0, Month0: month0, Day0: int8(DaysIn(r.Year(), time.Month(month0+1)) - 1)}
+}
+
+// ISOWeek returns the ISO 8601 year and week number of the RawDate. Weeks start on
+// Monday and week 1 is the week with the first Thursday of the year. So January 1 to 3
+// may belong to the last week of the year before and December 29 to 31 to week 1 of the
+// next year.
+func (r RawDate) ISOWeek() (year, week int) {
+	days := int(r.Days())
+	thursday := FromDays(Days(days - isoWeekday(days) + 4))
+	return thursday.Year(), (thursday.DayOfYear()-1)/7 + 1
+}
+
+// FromISOWeek returns the date of the weekday in the ISO 8601 week of the year.
+// It returns ErrISOWeek if the year has no such week (it has 52 or 53).
+func FromISOWeek(year, week int, weekday time.Weekday) (RawDate, error) {
+	if week < 1 || week > isoWeeksIn(year) {
+		return Zero, ErrISOWeek
+	}
+	jan4 := daysFromCivil(year, 1, 4)
+	monday := jan4 - isoWeekday(jan4) + 1
+	return FromDays(Days(monday + (week-1)*7 + isoWeekdayOf(weekday) - 1)), nil
+}
+
+// isoWeeksIn returns the number of ISO weeks of the year (December 28 is always in the last week)
+func isoWeeksIn(year int) int {
+	_, week := RawDate{Year0: year - 1, Month0: 11, Day0: 27}.ISOWeek()
+	return week
+}
+
+// isoWeekday returns the weekday of the days since 1970-01-01 (a Thursday) from Monday=1 to Sunday=7
+func isoWeekday(days int) int {
+	return (days + 3) - floorDiv(days+3, 7)*7 + 1
+}
+
+func isoWeekdayOf(weekday time.Weekday) int {
+	if weekday == time.Sunday {
+		return 7
+	}
+	return int(weekday)
+}
@@ -0,0 +1,185 @@
+package rawdate_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/metatexx/avrox/rawdate"
+)
+
+func TestDaysBetween(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b rawdate.RawDate
+		want int
+	}{
+		{"same day", ymd(2024, 3, 1), ymd(2024, 3, 1), 0},
+		{"leap day", ymd(2024, 2, 28), ymd(2024, 3, 1), 2},
+		{"no leap day", ymd(2023, 2, 28), ymd(2023, 3, 1), 1},
+		{"backwards", ymd(2024, 3, 1), ymd(2024, 2, 28), -2},
+		{"year", ymd(2023, 1, 1), ymd(2024, 1, 1), 365},
+		{"400 years", ymd(1600, 1, 1), ymd(2000, 1, 1), 146097},
+		{"from zero", rawdate.Zero, ymd(1970, 1, 1), 719162},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, rawdate.DaysBetween(tt.a, tt.b))
+		})
+	}
+}
+
+func TestMonthsBetween(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b rawdate.RawDate
+		want int
+	}{
+		{"same day", ymd(2024, 1, 15), ymd(2024, 1, 15), 0},
+		{"day before", ymd(2024, 1, 15), ymd(2024, 2, 14), 0},
+		{"one month", ymd(2024, 1, 15), ymd(2024, 2, 15), 1},
+		{"over year", ymd(2023, 11, 15), ymd(2024, 2, 20), 3},
+		{"end of february leap", ymd(2024, 1, 31), ymd(2024, 2, 29), 1},
+		{"end of february", ymd(2023, 1, 31), ymd(2023, 2, 28), 1},
+		{"before end of february", ymd(2024, 1, 31), ymd(2024, 2, 28), 0},
+		{"march 30", ymd(2024, 1, 31), ymd(2024, 3, 30), 1},
+		{"march 31", ymd(2024, 1, 31), ymd(2024, 3, 31), 2},
+		{"end of april", ymd(2024, 3, 31), ymd(2024, 4, 30), 1},
+		{"backwards", ymd(2024, 2, 15), ymd(2024, 1, 15), -1},
+		{"backwards partial", ymd(2024, 3, 10), ymd(2024, 1, 15), -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, rawdate.MonthsBetween(tt.a, tt.b))
+		})
+	}
+}
+
+func TestRawDate_Age(t *testing.T) {
+	tests := []struct {
+		name     string
+		birthday rawdate.RawDate
+		on       rawdate.RawDate
+		want     int
+	}{
+		{"newborn", ymd(2024, 5, 10), ymd(2024, 5, 10), 0},
+		{"day before birthday", ymd(1980, 5, 10), ymd(2024, 5, 9), 43},
+		{"birthday", ymd(1980, 5, 10), ymd(2024, 5, 10), 44},
+		{"leap day on feb 28", ymd(2000, 2, 29), ymd(2023, 2, 28), 23},
+		{"leap day on feb 27", ymd(2000, 2, 29), ymd(2023, 2, 27), 22},
+		{"leap day in leap year", ymd(2000, 2, 29), ymd(2024, 2, 28), 23},
+		{"leap day on leap day", ymd(2000, 2, 29), ymd(2024, 2, 29), 24},
+		{"not born yet", ymd(2024, 5, 10), ymd(2023, 5, 10), -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.birthday.Age(tt.on))
+		})
+	}
+}
+
+func TestRawDate_ISOWeek(t *testing.T) {
+	tests := []struct {
+		date    rawdate.RawDate
+		year    int
+		week    int
+		weekday time.Weekday
+	}{
+		{date: ymd(2024, 1, 1), year: 2024, week: 1, weekday: time.Monday},
+		{date: ymd(2023, 1, 1), year: 2022, week: 52, weekday: time.Sunday},
+		{date: ymd(2021, 1, 3), year: 2020, week: 53, weekday: time.Sunday},
+		{date: ymd(2024, 12, 30), year: 2025, week: 1, weekday: time.Monday},
+		{date: ymd(2026, 12, 31), year: 2026, week: 53, weekday: time.Thursday},
+		{date: ymd(1969, 12, 29), year: 1970, week: 1, weekday: time.Monday},
+		{date: ymd(1, 1, 1), year: 1, week: 1, weekday: time.Monday},
+	}
+	for _, tt := range tests {
+		t.Run(tt.date.String(), func(t *testing.T) {
+			year, week := tt.date.ISOWeek()
+			assert.Equal(t, tt.year, year)
+			assert.Equal(t, tt.week, week)
+			date, err := rawdate.FromISOWeek(tt.year, tt.week, tt.weekday)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.date, date)
+		})
+	}
+}
+
+func TestFromISOWeek_Invalid(t *testing.T) {
+	tests := []struct {
+		year, week int
+	}{
+		{2024, 0},
+		{2024, 53},
+		{2026, 54},
+		{2024, -1},
+	}
+	for _, tt := range tests {
+		_, err := rawdate.FromISOWeek(tt.year, tt.week, time.Monday)
+		assert.ErrorIs(t, err, rawdate.ErrISOWeek, "%d-W%d", tt.year, tt.week)
+	}
+}
+
+func TestRawDate_Quarter(t *testing.T) {
+	tests := []struct {
+		date    rawdate.RawDate
+		quarter int
+		start   rawdate.RawDate
+		end     rawdate.RawDate
+	}{
+		{ymd(2024, 1, 1), 1, ymd(2024, 1, 1), ymd(2024, 3, 31)},
+		{ymd(2024, 3, 31), 1, ymd(2024, 1, 1), ymd(2024, 3, 31)},
+		{ymd(2024, 5, 17), 2, ymd(2024, 4, 1), ymd(2024, 6, 30)},
+		{ymd(2024, 8, 1), 3, ymd(2024, 7, 1), ymd(2024, 9, 30)},
+		{ymd(2024, 12, 31), 4, ymd(2024, 10, 1), ymd(2024, 12, 31)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.date.String(), func(t *testing.T) {
+			assert.Equal(t, tt.quarter, tt.date.Quarter())
+			assert.Equal(t, tt.start, tt.date.QuarterStart())
+			assert.Equal(t, tt.end, tt.date.QuarterEnd())
+		})
+	}
+}
+
+func TestRawDate_Year(t *testing.T) {
+	tests := []struct {
+		date      rawdate.RawDate
+		dayOfYear int
+	}{
+		{ymd(2024, 1, 1), 1},
+		{ymd(2024, 3, 1), 61},
+		{ymd(2023, 3, 1), 60},
+		{ymd(2024, 12, 31), 366},
+		{ymd(2023, 12, 31), 365},
+		{rawdate.Zero, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.date.String(), func(t *testing.T) {
+			assert.Equal(t, tt.dayOfYear, tt.date.DayOfYear())
+			assert.Equal(t, ymd(tt.date.Year(), 1, 1), tt.date.YearStart())
+			assert.Equal(t, ymd(tt.date.Year(), 12, 31), tt.date.YearEnd())
+		})
+	}
+}
+
+// TestCalendarMatchesTime compares the calendar arithmetic with the time package
+func TestCalendarMatchesTime(t *testing.T) {
+	for tm := time.Date(1890, 12, 20, 0, 0, 0, 0, time.UTC); tm.Year() < 2110; tm = tm.AddDate(0, 0, 1) {
+		r := rawdate.FromTime(tm)
+		if days := r.Days(); days != rawdate.Days(tm.Unix()/86400) || rawdate.FromDays(days) != r {
+			t.Fatalf("Days of %s is %d", r, days)
+		}
+		if r.DayOfYear() != tm.YearDay() {
+			t.Fatalf("DayOfYear of %s is %d, want %d", r, r.DayOfYear(), tm.YearDay())
+		}
+		if daysIn := time.Date(tm.Year(), tm.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day(); rawdate.DaysIn(tm.Year(), tm.Month()) != daysIn {
+			t.Fatalf("DaysIn of %s is %d, want %d", r, rawdate.DaysIn(tm.Year(), tm.Month()), daysIn)
+		}
+		year, week := tm.ISOWeek()
+		if ry, rw := r.ISOWeek(); year != ry || week != rw {
+			t.Fatalf("ISOWeek of %s is %d-W%d, want %d-W%d", r, ry, rw, year, week)
+		}
+	}
+}
@@ -0,0 +1,162 @@
+package rawdate
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Clock provides the current time. Code which depends on "today" can take a Clock (or
+// use the one of its context) to be testable with a FakeClock.
+type Clock interface {
+	Now() time.Time
+}
+
+// ClockFunc adapts a function to the Clock interface
+type ClockFunc func() time.Time
+
+// Now returns the result of the function
+func (f ClockFunc) Now() time.Time {
+	return f()
+}
+
+// SystemClock is the Clock of time.Now
+var SystemClock Clock = systemClock{}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// clockHolder lets atomic.Pointer store the interface
+type clockHolder struct {
+	clock Clock
+}
+
+var packageClock atomic.Pointer[clockHolder]
+
+// SetClock replaces the package clock, which Today, TodayIn and NowIn use (nil restores
+// the SystemClock). It returns a function which restores the former clock:
+//
+//	defer rawdate.SetClock(rawdate.NewFakeClockAt(rawdate.MustNew(2026, 10, 16), nil))()
+//
+// WARNING: Like the SQL* variables the package clock is global, tests which set it should
+// not run in parallel. Prefer passing a Clock or using WithClock.
+func SetClock(c Clock) (restore func()) {
+	if c == nil {
+		c = SystemClock
+	}
+	former := packageClock.Swap(&clockHolder{clock: c})
+	return func() {
+		packageClock.Store(former)
+	}
+}
+
+// CurrentClock returns the package clock (see SetClock)
+func CurrentClock() Clock {
+	if h := packageClock.Load(); h != nil {
+		return h.clock
+	}
+	return SystemClock
+}
+
+// TodayIn returns the current date in the location of the package clock (see SetClock)
+func TodayIn(loc *time.Location) RawDate {
+	return TodayOn(CurrentClock(), loc)
+}
+
+// NowIn returns the current date and time of day in the location of the package clock
+func NowIn(loc *time.Location) RawDateTime {
+	return NowOn(CurrentClock(), loc)
+}
+
+// TodayOn returns the current date of the clock in the location (nil is UTC)
+func TodayOn(c Clock, loc *time.Location) RawDate {
+	return FromTime(nowOn(c, loc))
+}
+
+// NowOn returns the current date and time of day of the clock in the location (nil is UTC)
+func NowOn(c Clock, loc *time.Location) RawDateTime {
+	return DateTimeOf(nowOn(c, loc))
+}
+
+func nowOn(c Clock, loc *time.Location) time.Time {
+	if loc == nil {
+		loc = time.UTC
+	}
+	return c.Now().In(loc)
+}
+
+type clockKey struct{}
+
+// WithClock returns a copy of ctx which carries the clock
+func WithClock(ctx context.Context, c Clock) context.Context {
+	return context.WithValue(ctx, clockKey{}, c)
+}
+
+// ClockFromContext returns the clock of the context or the package clock if it has none
+func ClockFromContext(ctx context.Context) Clock {
+	if c, ok := ctx.Value(clockKey{}).(Clock); ok && c != nil {
+		return c
+	}
+	return CurrentClock()
+}
+
+// TodayInContext returns the current date in the location of the clock of the context
+func TodayInContext(ctx context.Context, loc *time.Location) RawDate {
+	return TodayOn(ClockFromContext(ctx), loc)
+}
+
+// FakeClock is a Clock for tests which only changes when it is told to.
+// It is safe for concurrent use.
+type FakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+var _ Clock = (*FakeClock)(nil)
+
+// NewFakeClock creates a FakeClock which is stopped at t
+func NewFakeClock(t time.Time) *FakeClock {
+	return &FakeClock{now: t}
+}
+
+// NewFakeClockAt creates a FakeClock which is stopped at midnight of the date in the
+// location (nil is UTC)
+func NewFakeClockAt(d RawDate, loc *time.Location) *FakeClock {
+	if loc == nil {
+		loc = time.UTC
+	}
+	return NewFakeClock(d.Time(loc))
+}
+
+// Now returns the time the clock is stopped at
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// Set stops the clock at t
+func (c *FakeClock) Set(t time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = t
+}
+
+// Advance moves the clock by d (which may be negative)
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// AdvanceDays moves the clock by n calendar days and keeps the wall clock time of day
+// (like time.Time.AddDate).
+func (c *FakeClock) AdvanceDays(n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.AddDate(0, 0, n)
+}
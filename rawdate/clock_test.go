@@ -0,0 +1,89 @@
+package rawdate_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/metatexx/avrox/rawdate"
+)
+
+func TestTodayIn(t *testing.T) {
+	// 2024-03-31 23:30 UTC is already April in Berlin (UTC+2) but not in New York
+	clock := rawdate.NewFakeClock(time.Date(2024, 3, 31, 23, 30, 0, 0, time.UTC))
+	tests := []struct {
+		loc  *time.Location
+		want rawdate.RawDate
+	}{
+		{nil, ymd(2024, 3, 31)},
+		{time.UTC, ymd(2024, 3, 31)},
+		{time.FixedZone("CEST", 2*3600), ymd(2024, 4, 1)},
+		{time.FixedZone("EDT", -4*3600), ymd(2024, 3, 31)},
+		{time.FixedZone("NZDT", 13*3600), ymd(2024, 4, 1)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want.String()+" "+tt.loc.String(), func(t *testing.T) {
+			assert.Equal(t, tt.want, rawdate.TodayOn(clock, tt.loc))
+		})
+	}
+	assert.Equal(t, ymd(2024, 4, 1).At(rawdate.MustNewTime(1, 30, 0, 0)), rawdate.NowOn(clock, time.FixedZone("CEST", 2*3600)))
+}
+
+func TestSetClock(t *testing.T) {
+	clock := rawdate.NewFakeClockAt(ymd(2024, 2, 28), time.Local)
+	restore := rawdate.SetClock(clock)
+	assert.Equal(t, ymd(2024, 2, 28), rawdate.Today())
+	clock.AdvanceDays(1)
+	assert.Equal(t, ymd(2024, 2, 29), rawdate.Today())
+	clock.Advance(24 * time.Hour)
+	assert.Equal(t, ymd(2024, 3, 1), rawdate.TodayIn(time.Local))
+	assert.Equal(t, ymd(2024, 3, 1).At(rawdate.Midnight), rawdate.NowIn(time.Local))
+	clock.Set(time.Date(2030, 1, 1, 12, 0, 0, 0, time.UTC))
+	assert.Equal(t, ymd(2030, 1, 1), rawdate.TodayIn(time.UTC))
+
+	// scheduling code which uses today gets deterministic
+	r := rawdate.MustParseRecurrence(ymd(2024, 1, 1), "FREQ=MONTHLY;BYDAY=2TU")
+	next, ok := r.After(rawdate.TodayIn(time.UTC), true)
+	assert.True(t, ok)
+	assert.Equal(t, ymd(2030, 1, 8), next)
+
+	restore()
+	assert.Equal(t, rawdate.SystemClock, rawdate.CurrentClock())
+	assert.WithinDuration(t, time.Now(), rawdate.CurrentClock().Now(), time.Minute)
+
+	restore = rawdate.SetClock(nil)
+	assert.Equal(t, rawdate.SystemClock, rawdate.CurrentClock())
+	restore()
+}
+
+func TestClockFromContext(t *testing.T) {
+	ctx := context.Background()
+	assert.Equal(t, rawdate.CurrentClock(), rawdate.ClockFromContext(ctx))
+
+	clock := rawdate.NewFakeClockAt(ymd(2024, 12, 24), nil)
+	ctx = rawdate.WithClock(ctx, clock)
+	assert.Equal(t, clock, rawdate.ClockFromContext(ctx))
+	assert.Equal(t, ymd(2024, 12, 24), rawdate.TodayInContext(ctx, time.UTC))
+	assert.Equal(t, ymd(2024, 12, 23), rawdate.TodayInContext(ctx, time.FixedZone("EST", -5*3600)))
+
+	fixed := rawdate.ClockFunc(func() time.Time { return time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC) })
+	assert.Equal(t, ymd(2000, 1, 1), rawdate.TodayInContext(rawdate.WithClock(ctx, fixed), nil))
+}
+
+func TestFakeClock_Concurrent(t *testing.T) {
+	clock := rawdate.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			clock.Advance(time.Hour)
+			_ = rawdate.TodayOn(clock, nil)
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC), clock.Now())
+}
@@ -0,0 +1,72 @@
+package rawdate
+
+// Days is a date as number of days since 1970-01-01 (which can be negative).
+// It is the value of the avro `date` logical type, so struct fields of this type can
+// be declared as {"type":"int","logicalType":"date"} and get encoded natively by hamba/avro.
+// The zero RawDate (0001-01-01) is -719162.
+//
+// RawDate fields themselves can not be declared as avro date (yet): hamba/avro only encodes
+// time.Time and int kinds as date. Its type converters (added after v2.20.1) are only used
+// for values held in interfaces, not for struct fields. Use Days for the fields and
+// RawDate (or Days.RawDate) in the code.
+type Days int32
+
+// Days returns the number of days since 1970-01-01
+func (r RawDate) Days() Days {
+	return Days(daysFromCivil(r.Year0+1, int(r.Month0)+1, int(r.Day0)+1))
+}
+
+// FromDays creates a RawDate from the number of days since 1970-01-01
+func FromDays(d Days) RawDate {
+	y, m, day := civilFromDays(int(d))
+	return RawDate{Year0: y - 1, Month0: int8(m - 1), Day0: int8(day - 1)}
+}
+
+// RawDate returns the date
+func (d Days) RawDate() RawDate {
+	return FromDays(d)
+}
+
+// String returns the date in YYYY-MM-DD format
+func (d Days) String() string {
+	return FromDays(d).String()
+}
+
+// daysFromCivil returns the days since 1970-01-01 for the proleptic gregorian date
+// (see https://howardhinnant.github.io/date_algorithms.html#days_from_civil)
+func daysFromCivil(y, m, d int) int {
+	if m <= 2 {
+		y--
+	}
+	era := floorDiv(y, 400)
+	yoe := y - era*400 // [0, 399]
+	mp := (m + 9) % 12 // March is 0
+	doy := (153*mp+2)/5 + d - 1
+	doe := yoe*365 + yoe/4 - yoe/100 + doy // [0, 146096]
+	return era*146097 + doe - 719468
+}
+
+// civilFromDays is the inverse of daysFromCivil
+func civilFromDays(z int) (y, m, d int) {
+	z += 719468
+	era := floorDiv(z, 146097)
+	doe := z - era*146097
+	yoe := (doe - doe/1460 + doe/36524 - doe/146096) / 365
+	doy := doe - (365*yoe + yoe/4 - yoe/100)
+	mp := (5*doy + 2) / 153
+	d = doy - (153*mp+2)/5 + 1
+	m = (mp+2)%12 + 1
+	y = yoe + era*400
+	if m <= 2 {
+		y++
+	}
+	return y, m, d
+}
+
+func floorDiv(a, b int) int {
+	q := a / b
+	if a%b != 0 && (a < 0) != (b < 0) {
+		q--
+	}
+	return q
+}
@@ -0,0 +1,205 @@
+package rawdate
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrNullDate is returned when NULL gets scanned into a RawDate with a Dialect without
+// a Null convention (use NullRawDate for nullable columns)
+var ErrNullDate = errors.New("NULL can not be scanned as RawDate")
+
+// Dialect configures how dates are stored in a database. Unlike the SQL* package
+// variables, a Dialect can be chosen per connection (or even per statement) by using
+// its adapters as arguments and scan destinations:
+//
+//	db.Exec("INSERT INTO t (d) VALUES (?)", rawdate.MSSQLLegacy.Valuer(date))
+//	db.QueryRow("SELECT d FROM t").Scan(rawdate.MSSQLLegacy.Scanner(&date))
+type Dialect struct {
+	Name        string
+	DateFormat  string         // Layout for string values
+	ZeroValue   string         // Stored for the zero RawDate (and scanned back as zero RawDate)
+	ValueTime   bool           // Stores time.Time values (at midnight in TimeZone) instead of strings
+	TimeZone    *time.Location // Location of the time.Time values (nil is UTC)
+	ScanFormats []string       // Additional layouts accepted for string values (the time must be midnight)
+	Null        NullConvention // Dates which get stored as NULL (and NULL scans to)
+}
+
+var (
+	// ISO stores ISO 8601 dates (YYYY-MM-DD) and the zero RawDate as 0001-01-01
+	ISO = Dialect{
+		Name:       "iso",
+		DateFormat: time.DateOnly,
+		ZeroValue:  "0001-01-01",
+	}
+
+	// SQLite stores ISO 8601 dates, which SQLite date functions understand, and the
+	// zero RawDate as 0000-01-01 (like the SQL* defaults). It also scans the timestamp
+	// strings the SQLite driver creates for time.Time values.
+	SQLite = Dialect{
+		Name:       "sqlite",
+		DateFormat: time.DateOnly,
+		ZeroValue:  "0000-01-01",
+		ScanFormats: []string{
+			"2006-01-02 15:04:05.999999999-07:00",
+			"2006-01-02T15:04:05.999999999-07:00",
+			"2006-01-02 15:04:05.999999999",
+			"2006-01-02T15:04:05.999999999",
+		},
+	}
+
+	// MSSQLLegacy stores dates as YYYYMMDD (MSSQLDateFmt) and the zero RawDate as 19000101
+	// (MSSQLZeroDate), which work with old MSSQL servers and char(8) columns.
+	MSSQLLegacy = Dialect{
+		Name:       "mssql-legacy",
+		DateFormat: MSSQLDateFmt,
+		ZeroValue:  MSSQLZeroDate,
+	}
+)
+
+// globalDialect is the dialect of the SQL* package variables
+func globalDialect() Dialect {
+	return Dialect{
+		Name:       "global",
+		DateFormat: SQLDateFormat,
+		ZeroValue:  SQLZeroValue,
+		ValueTime:  SQLValueTime,
+		TimeZone:   SQLTimeZone,
+	}
+}
+
+// Value returns the database value for the date (nil for the dates of the Null convention)
+func (dl Dialect) Value(d RawDate) (driver.Value, error) {
+	if dl.Null.IsNull(d) {
+		return nil, nil
+	}
+	if dl.ValueTime {
+		loc := dl.TimeZone
+		if loc == nil {
+			loc = time.UTC
+		}
+		return d.Time(loc), nil
+	}
+	if d.IsZero() && dl.ZeroValue != "" {
+		return dl.ZeroValue, nil
+	}
+	return d.Format(dl.DateFormat), nil
+}
+
+// FromValue returns the date for a database value (string, []byte or time.Time).
+// The ZeroValue results in the zero RawDate and NULL in the date of the Null convention
+// (or ErrNullDate without one, so Value does not store another value for NULL).
+func (dl Dialect) FromValue(src any) (RawDate, error) {
+	switch st := src.(type) {
+	case nil:
+		if len(dl.Null.Dates) == 0 {
+			return Zero, ErrNullDate
+		}
+		return dl.Null.RawDate(NullRawDate{}), nil
+	case string:
+		return dl.parse(st)
+	case []byte:
+		return dl.parse(string(st))
+	case time.Time:
+		return dl.fromTime(st)
+	default:
+		return Zero, fmt.Errorf("wrong type to scan as RawDate: type=%T", src)
+	}
+}
+
+func (dl Dialect) parse(s string) (RawDate, error) {
+	if s == dl.ZeroValue && s != "" {
+		return Zero, nil
+	}
+	rd, err := Parse(dl.DateFormat, s)
+	if err == nil {
+		return rd, nil
+	}
+	for _, layout := range dl.ScanFormats {
+		if t, errT := time.Parse(layout, s); errT == nil {
+			return dl.fromTime(t)
+		}
+	}
+	return Zero, err
+}
+
+func (dl Dialect) fromTime(t time.Time) (RawDate, error) {
+	if t.Hour() != 0 || t.Minute() != 0 || t.Second() != 0 || t.Nanosecond() != 0 {
+		return Zero, fmt.Errorf("timestamp contains time information: %s", t.String())
+	}
+	rd, err := New(t.Year(), t.Month(), t.Day())
+	if err != nil {
+		return Zero, fmt.Errorf("not a valid time for RawDate: %s", t.String())
+	}
+	if dl.ZeroValue != "" && rd.Format(dl.DateFormat) == dl.ZeroValue {
+		return Zero, nil
+	}
+	return rd, nil
+}
+
+// Valuer returns a driver.Valuer which stores the date with the dialect
+func (dl Dialect) Valuer(d RawDate) driver.Valuer {
+	return dialectDate{dialect: dl, date: &d}
+}
+
+// Scanner returns a sql.Scanner which scans into dst with the dialect
+func (dl Dialect) Scanner(dst *RawDate) sql.Scanner {
+	return dialectDate{dialect: dl, date: dst}
+}
+
+// NullValuer returns a driver.Valuer which stores the nullable date with the dialect
+func (dl Dialect) NullValuer(n NullRawDate) driver.Valuer {
+	return dialectNullDate{dialect: dl, date: &n}
+}
+
+// NullScanner returns a sql.Scanner which scans into dst with the dialect (NULL and the
+// dates of the Null convention are not valid)
+func (dl Dialect) NullScanner(dst *NullRawDate) sql.Scanner {
+	return dialectNullDate{dialect: dl, date: dst}
+}
+
+type dialectDate struct {
+	dialect Dialect
+	date    *RawDate
+}
+
+func (dd dialectDate) Value() (driver.Value, error) {
+	return dd.dialect.Value(*dd.date)
+}
+
+func (dd dialectDate) Scan(src any) error {
+	rd, err := dd.dialect.FromValue(src)
+	if err != nil {
+		return err
+	}
+	*dd.date = rd
+	return nil
+}
+
+type dialectNullDate struct {
+	dialect Dialect
+	date    *NullRawDate
+}
+
+func (dn dialectNullDate) Value() (driver.Value, error) {
+	if !dn.date.Valid {
+		return nil, nil
+	}
+	return dn.dialect.Value(dn.date.RawDate)
+}
+
+func (dn dialectNullDate) Scan(src any) error {
+	if src == nil {
+		*dn.date = NullRawDate{}
+		return nil
+	}
+	rd, err := dn.dialect.FromValue(src)
+	if err != nil {
+		return err
+	}
+	*dn.date = dn.dialect.Null.Nullable(rd)
+	return nil
+}
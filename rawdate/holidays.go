@@ -0,0 +1,199 @@
+package rawdate
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"time"
+)
+
+// Holiday is a named day which is not a business day
+type Holiday struct {
+	Date RawDate
+	Name string
+}
+
+// HolidayProvider returns the holidays of a year (ordered by date)
+type HolidayProvider interface {
+	Holidays(year int) []Holiday
+}
+
+// RuleKind selects how a HolidayRule determines its date
+type RuleKind string
+
+const (
+	RuleFixed      RuleKind = "fixed"   // Month and Day
+	RuleEaster     RuleKind = "easter"  // Easter Sunday (plus Offset)
+	RuleNthWeekday RuleKind = "weekday" // N-th Weekday counted from Day of Month
+)
+
+var ErrHolidayRule = errors.New("holiday rule is invalid")
+
+// HolidayRule defines a holiday by a rule. The date of the rule gets Offset days added, so
+// Good Friday is {Kind: RuleEaster, Offset: -2} and the Monday after the first Sunday in
+// October would be {Kind: RuleNthWeekday, Month: 10, Weekday: 0, N: 1, Offset: 1}.
+//
+// For RuleNthWeekday a positive N counts the weekdays on or after Day (default 1) and a
+// negative N the weekdays on or before Day (default the last day of the month). So N=-1
+// is the last Weekday of the month, and N=-1 with Day=22 in November is the Wednesday
+// before November 23 (Buß- und Bettag).
+//
+// FromYear and ToYear limit the years in which the holiday exists (0 for no limit).
+// The JSON field names are used by LoadHolidayRules, Weekday is a number (0 = Sunday).
+type HolidayRule struct {
+	Name     string       `json:"name"`
+	Kind     RuleKind     `json:"kind"`
+	Month    time.Month   `json:"month,omitempty"`
+	Day      int          `json:"day,omitempty"`
+	Weekday  time.Weekday `json:"weekday,omitempty"`
+	N        int          `json:"n,omitempty"`
+	Offset   int          `json:"offset,omitempty"`
+	FromYear int          `json:"from,omitempty"`
+	ToYear   int          `json:"to,omitempty"`
+}
+
+// maxHolidayOffset keeps the holidays within the year before or after the one of the rule
+// (BusinessCalendar only looks at those)
+const maxHolidayOffset = 365
+
+// Validate checks that the rule can be used
+func (h HolidayRule) Validate() error {
+	invalid := func(reason string) error {
+		return fmt.Errorf("%w: %q %s", ErrHolidayRule, h.Name, reason)
+	}
+	switch h.Kind {
+	case RuleFixed:
+		if h.Month < time.January || h.Month > time.December || h.Day < 1 || h.Day > DaysIn(2000, h.Month) {
+			return invalid("needs a valid month and day")
+		}
+	case RuleEaster:
+	case RuleNthWeekday:
+		if h.Month < time.January || h.Month > time.December || h.Day < 0 || h.Day > DaysIn(2000, h.Month) {
+			return invalid("needs a valid month (and day)")
+		}
+		if h.N == 0 || h.N < -5 || h.N > 5 || h.Weekday < time.Sunday || h.Weekday > time.Saturday {
+			return invalid("needs a weekday and n between -5 and 5 (not 0)")
+		}
+	default:
+		return invalid("has an unknown kind")
+	}
+	if h.ToYear != 0 && h.ToYear < h.FromYear {
+		return invalid("ends before it starts")
+	}
+	if h.Offset < -maxHolidayOffset || h.Offset > maxHolidayOffset {
+		return invalid("needs an offset of at most 365 days")
+	}
+	return nil
+}
+
+// Date returns the date of the holiday in the year. It returns false if the holiday
+// does not exist in that year.
+func (h HolidayRule) Date(year int) (RawDate, bool) {
+	if (h.FromYear != 0 && year < h.FromYear) || (h.ToYear != 0 && year > h.ToYear) {
+		return Zero, false
+	}
+	var days int
+	switch h.Kind {
+	case RuleFixed:
+		if h.Day > DaysIn(year, h.Month) {
+			return Zero, false
+		}
+		days = daysFromCivil(year, int(h.Month), h.Day)
+	case RuleEaster:
+		days = int(Easter(year).Days())
+	case RuleNthWeekday:
+		last := DaysIn(year, h.Month)
+		wd := isoWeekdayOf(h.Weekday)
+		if h.N > 0 {
+			anchor := daysFromCivil(year, int(h.Month), max(h.Day, 1))
+			days = anchor + (wd-isoWeekday(anchor)+7)%7 + (h.N-1)*7
+		} else {
+			day := last
+			if h.Day > 0 {
+				day = h.Day
+			}
+			anchor := daysFromCivil(year, int(h.Month), day)
+			days = anchor - (isoWeekday(anchor)-wd+7)%7 + (h.N+1)*7
+		}
+		if days < daysFromCivil(year, int(h.Month), 1) || days > daysFromCivil(year, int(h.Month), last) {
+			return Zero, false
+		}
+	default:
+		return Zero, false
+	}
+	return FromDays(Days(days + h.Offset)), true
+}
+
+// HolidayRules is a HolidayProvider for a set of rules
+type HolidayRules []HolidayRule
+
+// Holidays returns the holidays of the rules in the year (ordered by date)
+func (rules HolidayRules) Holidays(year int) []Holiday {
+	var holidays []Holiday
+	for _, rule := range rules {
+		if date, ok := rule.Date(year); ok {
+			holidays = append(holidays, Holiday{Date: date, Name: rule.Name})
+		}
+	}
+	sort.SliceStable(holidays, func(i, j int) bool { return holidays[i].Date.Before(holidays[j].Date) })
+	return holidays
+}
+
+// Validate checks all rules
+func (rules HolidayRules) Validate() error {
+	for _, rule := range rules {
+		if err := rule.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReadHolidayRules reads a JSON array of HolidayRule and validates the rules
+func ReadHolidayRules(r io.Reader) (HolidayRules, error) {
+	var rules HolidayRules
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&rules); err != nil {
+		return nil, errors.Join(ErrHolidayRule, err)
+	}
+	if err := rules.Validate(); err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+// LoadHolidayRules reads the rules from a JSON file (see ReadHolidayRules). Custom rules
+// can be combined with a built-in set:
+//
+//	rules, err := GermanHolidays(StateBY)
+//	...
+//	calendar := NewBusinessCalendar(append(rules, custom...))
+func LoadHolidayRules(name string) (HolidayRules, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ReadHolidayRules(f)
+}
+
+// Easter returns Easter Sunday of the year (gregorian calendar)
+func Easter(year int) RawDate {
+	// anonymous gregorian algorithm (Meeus/Jones/Butcher)
+	a := year % 19
+	b, c := year/100, year%100
+	d, e := b/4, b%4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i, k := c/4, c%4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	day := (h+l-7*m+114)%31 + 1
+	return RawDate{Year0: year - 1, Month0: int8(month - 1), Day0: int8(day - 1)}
+}
@@ -0,0 +1,112 @@
+package rawdate
+
+import (
+	"errors"
+	"time"
+)
+
+// GermanState is the ISO 3166-2 code (without "DE-") of a German federal state
+type GermanState string
+
+const (
+	StateBW GermanState = "BW" // Baden-Württemberg
+	StateBY GermanState = "BY" // Bayern
+	StateBE GermanState = "BE" // Berlin
+	StateBB GermanState = "BB" // Brandenburg
+	StateHB GermanState = "HB" // Bremen
+	StateHH GermanState = "HH" // Hamburg
+	StateHE GermanState = "HE" // Hessen
+	StateMV GermanState = "MV" // Mecklenburg-Vorpommern
+	StateNI GermanState = "NI" // Niedersachsen
+	StateNW GermanState = "NW" // Nordrhein-Westfalen
+	StateRP GermanState = "RP" // Rheinland-Pfalz
+	StateSL GermanState = "SL" // Saarland
+	StateSN GermanState = "SN" // Sachsen
+	StateST GermanState = "ST" // Sachsen-Anhalt
+	StateSH GermanState = "SH" // Schleswig-Holstein
+	StateTH GermanState = "TH" // Thüringen
+)
+
+// GermanStates lists all German federal states
+var GermanStates = []GermanState{
+	StateBW, StateBY, StateBE, StateBB, StateHB, StateHH, StateHE, StateMV,
+	StateNI, StateNW, StateRP, StateSL, StateSN, StateST, StateSH, StateTH,
+}
+
+var ErrUnknownState = errors.New("unknown german state")
+
+// germanHolidays are the nationwide holidays
+var germanHolidays = HolidayRules{
+	{Name: "Neujahr", Kind: RuleFixed, Month: time.January, Day: 1},
+	{Name: "Karfreitag", Kind: RuleEaster, Offset: -2},
+	{Name: "Ostermontag", Kind: RuleEaster, Offset: 1},
+	{Name: "Tag der Arbeit", Kind: RuleFixed, Month: time.May, Day: 1},
+	{Name: "Christi Himmelfahrt", Kind: RuleEaster, Offset: 39},
+	{Name: "Pfingstmontag", Kind: RuleEaster, Offset: 50},
+	{Name: "Tag der Deutschen Einheit", Kind: RuleFixed, Month: time.October, Day: 3, FromYear: 1990},
+	{Name: "1. Weihnachtstag", Kind: RuleFixed, Month: time.December, Day: 25},
+	{Name: "2. Weihnachtstag", Kind: RuleFixed, Month: time.December, Day: 26},
+}
+
+// germanStateHolidays are the holidays of the states (the whole state, not only some
+// communities) and the states which have them
+var germanStateHolidays = []struct {
+	rule   HolidayRule
+	states []GermanState
+}{
+	{HolidayRule{Name: "Heilige Drei Könige", Kind: RuleFixed, Month: time.January, Day: 6},
+		[]GermanState{StateBW, StateBY, StateST}},
+	{HolidayRule{Name: "Internationaler Frauentag", Kind: RuleFixed, Month: time.March, Day: 8, FromYear: 2019},
+		[]GermanState{StateBE}},
+	{HolidayRule{Name: "Internationaler Frauentag", Kind: RuleFixed, Month: time.March, Day: 8, FromYear: 2023},
+		[]GermanState{StateMV}},
+	{HolidayRule{Name: "Ostersonntag", Kind: RuleEaster},
+		[]GermanState{StateBB}},
+	{HolidayRule{Name: "Tag der Befreiung", Kind: RuleFixed, Month: time.May, Day: 8, FromYear: 2020, ToYear: 2020},
+		[]GermanState{StateBE}},
+	{HolidayRule{Name: "Tag der Befreiung", Kind: RuleFixed, Month: time.May, Day: 8, FromYear: 2025, ToYear: 2025},
+		[]GermanState{StateBE}},
+	{HolidayRule{Name: "Pfingstsonntag", Kind: RuleEaster, Offset: 49},
+		[]GermanState{StateBB}},
+	{HolidayRule{Name: "Fronleichnam", Kind: RuleEaster, Offset: 60},
+		[]GermanState{StateBW, StateBY, StateHE, StateNW, StateRP, StateSL}},
+	{HolidayRule{Name: "Mariä Himmelfahrt", Kind: RuleFixed, Month: time.August, Day: 15},
+		[]GermanState{StateSL}},
+	{HolidayRule{Name: "Weltkindertag", Kind: RuleFixed, Month: time.September, Day: 20, FromYear: 2019},
+		[]GermanState{StateTH}},
+	{HolidayRule{Name: "Reformationstag", Kind: RuleFixed, Month: time.October, Day: 31},
+		[]GermanState{StateBB, StateMV, StateSN, StateST, StateTH}},
+	{HolidayRule{Name: "Reformationstag", Kind: RuleFixed, Month: time.October, Day: 31, FromYear: 2017, ToYear: 2017},
+		[]GermanState{StateBW, StateBY, StateBE, StateHB, StateHH, StateHE, StateNI, StateNW, StateRP, StateSL, StateSH}},
+	{HolidayRule{Name: "Reformationstag", Kind: RuleFixed, Month: time.October, Day: 31, FromYear: 2018},
+		[]GermanState{StateHB, StateHH, StateNI, StateSH}},
+	{HolidayRule{Name: "Allerheiligen", Kind: RuleFixed, Month: time.November, Day: 1},
+		[]GermanState{StateBW, StateBY, StateNW, StateRP, StateSL}},
+	{HolidayRule{Name: "Buß- und Bettag", Kind: RuleNthWeekday, Month: time.November, Day: 22, Weekday: time.Wednesday, N: -1},
+		[]GermanState{StateSN}},
+}
+
+// GermanHolidays returns the rules for the public holidays of the German state. An empty
+// state returns the nationwide holidays only. Holidays of only some communities (like
+// Mariä Himmelfahrt in parts of Bavaria) are not included.
+func GermanHolidays(state GermanState) (HolidayRules, error) {
+	rules := append(HolidayRules(nil), germanHolidays...)
+	if state == "" {
+		return rules, nil
+	}
+	known := false
+	for _, s := range GermanStates {
+		known = known || s == state
+	}
+	if !known {
+		return nil, ErrUnknownState
+	}
+	for _, sh := range germanStateHolidays {
+		for _, s := range sh.states {
+			if s == state {
+				rules = append(rules, sh.rule)
+			}
+		}
+	}
+	return rules, nil
+}
@@ -15,6 +15,8 @@ import (
 // concurrent routines, especially if different routines expect different settings.
 // Primarily, these should be set according to the specific database variant in use.
 // Caution is advised as these settings also affect imported packages that rely on them.
+// Use a Dialect (ISO, SQLite, MSSQLLegacy or an own one) to choose the behavior per
+// connection instead.
 
 var SQLDateFormat = "2006-01-02" // This is the format for the DB when Parse uses a string (default)
 var SQLZeroValue = "0000-01-01"  // This is what is used as Zero value in the DB (only with Parse using string)
@@ -76,17 +78,9 @@ func (d *RawDate) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
-// Value implements `driver.Valuer`; marshals to a string
+// Value implements `driver.Valuer`; marshals to a string (using the SQL* variables)
 func (d RawDate) Value() (driver.Value, error) {
-	if SQLValueTime {
-		return d.ValueTime()
-	}
-	// using 1900-01-01 as zero value when the given date is the zero value
-	if d.IsZero() {
-		return SQLZeroValue, nil
-	}
-	// the internal format is UTC make sure we use local time here!
-	return d.Format(SQLDateFormat), nil
+	return globalDialect().Value(d)
 }
 
 // This implementation will use the time.Time serialisation
@@ -100,32 +94,7 @@ func (d RawDate) GoString() string {
 	return fmt.Sprintf("rawdate.MustNew(%d, time.%s, %d)", d.Year0+1, time.Month(d.Month0+1), d.Day0+1)
 }
 
-// Scan implements `sql.Scanner` by unmarshalling from `time.Time`
+// Scan implements `sql.Scanner` by unmarshalling from `time.Time` or a string (using the SQL* variables)
 func (d *RawDate) Scan(src any) error {
-	var rd RawDate
-	var err error
-
-	switch st := src.(type) {
-	case string:
-		rd, err = Parse(SQLDateFormat, st)
-		if err != nil {
-			return err
-		}
-
-	case time.Time:
-		if st.Hour() != 0 || st.Minute() != 0 || st.Second() != 0 || st.Nanosecond() != 0 {
-			return fmt.Errorf("timestamp contains time information: %s", st.String())
-		}
-		rd, err = New(st.Year(), st.Month(), st.Day())
-		if err != nil {
-			return err
-		}
-		if !rd.IsValid() {
-			return fmt.Errorf("not a valid time for RawDate: %s", st.String())
-		}
-	default:
-		return fmt.Errorf("wrong type to scan as RawDate: type=%T", src)
-	}
-	*d = rd
-	return nil
+	return globalDialect().Scanner(d).Scan(src)
 }
@@ -0,0 +1,169 @@
+package rawdate
+
+import (
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Locale holds the names of months and weekdays of a language. FormatLocale uses them for
+// the January, Jan, Monday and Mon elements of a layout and Parser accepts them in input.
+// Empty names fall back to the English ones.
+type Locale struct {
+	Tag           string     // Language tag like "de"
+	Months        [12]string // January first
+	ShortMonths   [12]string
+	Weekdays      [7]string // Sunday first (like time.Weekday)
+	ShortWeekdays [7]string
+	DateLayout    string // Numeric date layout, like "02.01.2006"
+	LongLayout    string // Date layout with the month name, like "2. January 2006"
+}
+
+var (
+	// LocaleEN has the English (US) names and layouts
+	LocaleEN = Locale{
+		Tag: "en",
+		Months: [12]string{"January", "February", "March", "April", "May", "June",
+			"July", "August", "September", "October", "November", "December"},
+		ShortMonths: [12]string{"Jan", "Feb", "Mar", "Apr", "May", "Jun",
+			"Jul", "Aug", "Sep", "Oct", "Nov", "Dec"},
+		Weekdays:      [7]string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
+		ShortWeekdays: [7]string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"},
+		DateLayout:    "01/02/2006",
+		LongLayout:    "January 2, 2006",
+	}
+
+	// LocaleDE has the German names and layouts
+	LocaleDE = Locale{
+		Tag: "de",
+		Months: [12]string{"Januar", "Februar", "März", "April", "Mai", "Juni",
+			"Juli", "August", "September", "Oktober", "November", "Dezember"},
+		ShortMonths: [12]string{"Jan", "Feb", "Mär", "Apr", "Mai", "Jun",
+			"Jul", "Aug", "Sep", "Okt", "Nov", "Dez"},
+		Weekdays:      [7]string{"Sonntag", "Montag", "Dienstag", "Mittwoch", "Donnerstag", "Freitag", "Samstag"},
+		ShortWeekdays: [7]string{"So", "Mo", "Di", "Mi", "Do", "Fr", "Sa"},
+		DateLayout:    "02.01.2006",
+		LongLayout:    "2. January 2006",
+	}
+
+	// Locales are the locales LookupLocale knows
+	Locales = []Locale{LocaleEN, LocaleDE}
+)
+
+// LookupLocale returns the locale for a language tag like "de", "de-DE" or "de_AT"
+func LookupLocale(tag string) (Locale, bool) {
+	lang, _, _ := strings.Cut(strings.ReplaceAll(tag, "_", "-"), "-")
+	for _, loc := range Locales {
+		if strings.EqualFold(loc.Tag, lang) {
+			return loc, true
+		}
+	}
+	return Locale{}, false
+}
+
+// FormatLocale formats the RawDate like Format but uses the month and weekday names of
+// the locale.
+func (r RawDate) FormatLocale(layout string, loc Locale) string {
+	t := r.Time(time.UTC)
+	var b strings.Builder
+	for layout != "" {
+		i, elem := nextNameElem(layout)
+		b.WriteString(t.Format(layout[:i]))
+		if elem == "" {
+			break
+		}
+		b.WriteString(loc.name(elem, t))
+		layout = layout[i+len(elem):]
+	}
+	return b.String()
+}
+
+// FormatDate formats the RawDate with the DateLayout of the locale
+func (r RawDate) FormatDate(loc Locale) string {
+	return r.FormatLocale(loc.DateLayout, loc)
+}
+
+// FormatLong formats the RawDate with the LongLayout of the locale
+func (r RawDate) FormatLong(loc Locale) string {
+	return r.FormatLocale(loc.LongLayout, loc)
+}
+
+// nextNameElem finds the next layout element for a month or weekday name (the same way
+// the time package does it) and returns its index and the element ("" if there is none).
+func nextNameElem(layout string) (int, string) {
+	for i := 0; i < len(layout); i++ {
+		s := layout[i:]
+		for _, elem := range []string{"January", "Jan", "Monday", "Mon"} {
+			if !strings.HasPrefix(s, elem) {
+				continue
+			}
+			if len(elem) == 3 && len(s) > 3 && 'a' <= s[3] && s[3] <= 'z' {
+				continue // like "Month", which is no element in the time package
+			}
+			return i, elem
+		}
+	}
+	return len(layout), ""
+}
+
+// name returns the name in the locale for a layout element
+func (loc Locale) name(elem string, t time.Time) string {
+	var name string
+	switch elem {
+	case "January":
+		name = loc.Months[t.Month()-1]
+	case "Jan":
+		name = loc.ShortMonths[t.Month()-1]
+	case "Monday":
+		name = loc.Weekdays[t.Weekday()]
+	case "Mon":
+		name = loc.ShortWeekdays[t.Weekday()]
+	}
+	if name == "" {
+		return t.Format(elem)
+	}
+	return name
+}
+
+// toEnglish replaces the names of the locale in s by the English ones (which time.Parse
+// understands). Names are compared case-insensitive and only as whole words.
+func (loc Locale) toEnglish(s string) string {
+	var b strings.Builder
+	for s != "" {
+		start := strings.IndexFunc(s, unicode.IsLetter)
+		if start < 0 {
+			b.WriteString(s)
+			break
+		}
+		end := strings.IndexFunc(s[start:], func(r rune) bool { return !unicode.IsLetter(r) })
+		if end < 0 {
+			end = len(s)
+		} else {
+			end += start
+		}
+		b.WriteString(s[:start])
+		b.WriteString(loc.englishName(s[start:end]))
+		s = s[end:]
+	}
+	return b.String()
+}
+
+func (loc Locale) englishName(word string) string {
+	for i := range loc.Months {
+		switch {
+		case strings.EqualFold(word, loc.Months[i]):
+			return LocaleEN.Months[i]
+		case strings.EqualFold(word, loc.ShortMonths[i]):
+			return LocaleEN.ShortMonths[i]
+		}
+	}
+	for i := range loc.Weekdays {
+		switch {
+		case strings.EqualFold(word, loc.Weekdays[i]):
+			return LocaleEN.Weekdays[i]
+		case strings.EqualFold(word, loc.ShortWeekdays[i]):
+			return LocaleEN.ShortWeekdays[i]
+		}
+	}
+	return word
+}
@@ -0,0 +1,54 @@
+package rawdate_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/metatexx/avrox/rawdate"
+)
+
+func TestRawDate_FormatLocale(t *testing.T) {
+	tests := []struct {
+		date   rawdate.RawDate
+		layout string
+		loc    rawdate.Locale
+		want   string
+	}{
+		{ymd(2026, 10, 16), "Monday, 2. January 2006", rawdate.LocaleDE, "Freitag, 16. Oktober 2026"},
+		{ymd(2026, 3, 1), "Mon, 02. Jan 2006", rawdate.LocaleDE, "So, 01. Mär 2026"},
+		{ymd(2026, 10, 16), "Monday, January 2, 2006", rawdate.LocaleEN, "Friday, October 16, 2026"},
+		{ymd(2026, 10, 16), "Mon Jan 2 2006", rawdate.LocaleEN, "Fri Oct 16 2026"},
+		{ymd(2026, 5, 4), "Month: January", rawdate.LocaleDE, "Month: Mai"},
+		{ymd(2026, 5, 4), "January", rawdate.Locale{}, "May"},
+		{ymd(2026, 5, 4), "02.01.2006", rawdate.LocaleDE, "04.05.2026"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.date.FormatLocale(tt.layout, tt.loc))
+		})
+	}
+
+	d := ymd(2026, 10, 6)
+	assert.Equal(t, "06.10.2026", d.FormatDate(rawdate.LocaleDE))
+	assert.Equal(t, "6. Oktober 2026", d.FormatLong(rawdate.LocaleDE))
+	assert.Equal(t, "10/06/2026", d.FormatDate(rawdate.LocaleEN))
+	assert.Equal(t, "October 6, 2026", d.FormatLong(rawdate.LocaleEN))
+
+	// formatted long dates can be parsed again
+	for _, loc := range rawdate.Locales {
+		got, err := rawdate.ParseAny(d.FormatLong(loc))
+		assert.NoError(t, err)
+		assert.Equal(t, d, got)
+	}
+}
+
+func TestLookupLocale(t *testing.T) {
+	for tag, want := range map[string]string{"de": "de", "de-DE": "de", "de_AT": "de", "EN-us": "en"} {
+		loc, ok := rawdate.LookupLocale(tag)
+		assert.True(t, ok, tag)
+		assert.Equal(t, want, loc.Tag)
+	}
+	_, ok := rawdate.LookupLocale("fr")
+	assert.False(t, ok)
+}
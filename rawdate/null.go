@@ -0,0 +1,177 @@
+package rawdate
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"encoding/json"
+	"fmt"
+)
+
+// NullRawDate is a RawDate which may be missing (like sql.NullTime). It is JSON null,
+// SQL NULL and the null branch of an avro ["null", date] union (see Days). Its Value and
+// Scan use the SQL* package variables, use the adapters of a Dialect for other conventions.
+type NullRawDate struct {
+	RawDate RawDate
+	Valid   bool // Valid is true if RawDate is not missing
+}
+
+var (
+	_ fmt.Stringer             = NullRawDate{}
+	_ encoding.TextMarshaler   = NullRawDate{}
+	_ json.Marshaler           = NullRawDate{}
+	_ driver.Valuer            = NullRawDate{}
+	_ encoding.TextUnmarshaler = (*NullRawDate)(nil)
+	_ json.Unmarshaler         = (*NullRawDate)(nil)
+	_ sql.Scanner              = (*NullRawDate)(nil)
+)
+
+// NewNullRawDate creates a valid NullRawDate
+func NewNullRawDate(d RawDate) NullRawDate {
+	return NullRawDate{RawDate: d, Valid: true}
+}
+
+// NullRawDateFromPtr creates a NullRawDate which is missing for a nil pointer
+func NullRawDateFromPtr(d *RawDate) NullRawDate {
+	if d == nil {
+		return NullRawDate{}
+	}
+	return NewNullRawDate(*d)
+}
+
+// Ptr returns the date or nil if it is missing
+func (n NullRawDate) Ptr() *RawDate {
+	if !n.Valid {
+		return nil
+	}
+	d := n.RawDate
+	return &d
+}
+
+// NullRawDateFromDays creates a NullRawDate from the value of an avro ["null", date] union
+func NullRawDateFromDays(d *Days) NullRawDate {
+	if d == nil {
+		return NullRawDate{}
+	}
+	return NewNullRawDate(d.RawDate())
+}
+
+// Days returns the value for an avro ["null", date] union. Struct fields of type *Days are
+// encoded natively by hamba/avro with the schema ["null",{"type":"int","logicalType":"date"}].
+func (n NullRawDate) Days() *Days {
+	if !n.Valid {
+		return nil
+	}
+	d := n.RawDate.Days()
+	return &d
+}
+
+// String returns the date in YYYY-MM-DD format or "null" if it is missing
+func (n NullRawDate) String() string {
+	if !n.Valid {
+		return "null"
+	}
+	return n.RawDate.String()
+}
+
+// MarshalText implements the encoding.TextMarshaler (empty if the date is missing)
+func (n NullRawDate) MarshalText() ([]byte, error) {
+	if !n.Valid {
+		return []byte{}, nil
+	}
+	return n.RawDate.MarshalText()
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler (empty text is a missing date)
+func (n *NullRawDate) UnmarshalText(data []byte) error {
+	if len(data) == 0 {
+		*n = NullRawDate{}
+		return nil
+	}
+	var d RawDate
+	if err := d.UnmarshalText(data); err != nil {
+		return err
+	}
+	*n = NewNullRawDate(d)
+	return nil
+}
+
+// MarshalJSON implements `json.Marshaler` using YYYY-MM-DD or null
+func (n NullRawDate) MarshalJSON() ([]byte, error) {
+	if !n.Valid {
+		return []byte("null"), nil
+	}
+	return n.RawDate.MarshalJSON()
+}
+
+// UnmarshalJSON implements `json.Unmarshaler` for YYYY-MM-DD or null
+func (n *NullRawDate) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		*n = NullRawDate{}
+		return nil
+	}
+	var d RawDate
+	if err := d.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	*n = NewNullRawDate(d)
+	return nil
+}
+
+// Value implements `driver.Valuer` (NULL if the date is missing)
+func (n NullRawDate) Value() (driver.Value, error) {
+	return globalDialect().NullValuer(n).Value()
+}
+
+// Scan implements `sql.Scanner` (NULL is a missing date)
+func (n *NullRawDate) Scan(src any) error {
+	return globalDialect().NullScanner(n).Scan(src)
+}
+
+// NullConvention lists dates which stand for a missing date in data without a null value,
+// like the zero RawDate or the SQLZeroValue of a database. A Dialect applies its Null
+// convention to SQL values. JSON, text and avro (["null", date]) only know null as missing
+// date, use Nullable and RawDate to convert before marshalling or after unmarshalling.
+// The zero value has no such dates.
+type NullConvention struct {
+	Dates []RawDate // The first one is used for missing dates by RawDate
+}
+
+var (
+	// ZeroIsNull treats the zero RawDate (0001-01-01) as missing date
+	ZeroIsNull = NullConvention{Dates: []RawDate{Zero}}
+	// MSSQLZeroIsNull treats the zero RawDate and 1900-01-01 (MSSQLZeroDate) as missing date
+	MSSQLZeroIsNull = NullConvention{Dates: []RawDate{Zero, {Year0: 1899}}}
+)
+
+// IsNull reports whether the date stands for a missing date
+func (c NullConvention) IsNull(d RawDate) bool {
+	for _, nd := range c.Dates {
+		if nd == d {
+			return true
+		}
+	}
+	return false
+}
+
+// Nullable returns the NullRawDate for d, which is missing if d stands for a missing date
+func (c NullConvention) Nullable(d RawDate) NullRawDate {
+	if c.IsNull(d) {
+		return NullRawDate{}
+	}
+	return NewNullRawDate(d)
+}
+
+// RawDate returns the date of n, or the first date of the convention (Zero if there is
+// none) if it is missing
+func (c NullConvention) RawDate(n NullRawDate) RawDate {
+	switch {
+	case n.Valid:
+		return n.RawDate
+	case len(c.Dates) > 0:
+		return c.Dates[0]
+	default:
+		return Zero
+	}
+}
@@ -0,0 +1,117 @@
+package rawdate_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/metatexx/avrox/rawdate"
+)
+
+func TestNullRawDate_JSON(t *testing.T) {
+	type Contract struct {
+		Start rawdate.NullRawDate `json:"start"`
+		End   rawdate.NullRawDate `json:"end"`
+	}
+	tests := []struct {
+		name     string
+		contract Contract
+		json     string
+	}{
+		{"both", Contract{rawdate.NewNullRawDate(ymd(2024, 1, 1)), rawdate.NewNullRawDate(ymd(2024, 12, 31))},
+			`{"start":"2024-01-01","end":"2024-12-31"}`},
+		{"open end", Contract{Start: rawdate.NewNullRawDate(ymd(2024, 1, 1))}, `{"start":"2024-01-01","end":null}`},
+		{"zero is not null", Contract{Start: rawdate.NewNullRawDate(rawdate.Zero)}, `{"start":"0001-01-01","end":null}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(tt.contract)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.json, string(data))
+			var got Contract
+			assert.NoError(t, json.Unmarshal(data, &got))
+			assert.Equal(t, tt.contract, got)
+		})
+	}
+
+	var n rawdate.NullRawDate
+	assert.NoError(t, json.Unmarshal([]byte(`{}`), &struct{ D *rawdate.NullRawDate }{&n}))
+	assert.False(t, n.Valid)
+	assert.Error(t, json.Unmarshal([]byte(`"2024-02-30"`), &n))
+}
+
+func TestNullRawDate_Text(t *testing.T) {
+	var n rawdate.NullRawDate
+	assert.NoError(t, n.UnmarshalText([]byte("2024-02-29")))
+	assert.Equal(t, rawdate.NewNullRawDate(ymd(2024, 2, 29)), n)
+	text, err := n.MarshalText()
+	assert.NoError(t, err)
+	assert.Equal(t, "2024-02-29", string(text))
+
+	assert.NoError(t, n.UnmarshalText(nil))
+	assert.False(t, n.Valid)
+	text, err = n.MarshalText()
+	assert.NoError(t, err)
+	assert.Empty(t, text)
+	assert.Equal(t, "null", n.String())
+	assert.Nil(t, n.Ptr())
+	assert.Nil(t, n.Days())
+
+	d := ymd(1960, 1, 1)
+	assert.Equal(t, &d, rawdate.NullRawDateFromPtr(&d).Ptr())
+	assert.Equal(t, rawdate.NewNullRawDate(d), rawdate.NullRawDateFromDays(rawdate.NewNullRawDate(d).Days()))
+}
+
+func TestNullConvention(t *testing.T) {
+	tests := []struct {
+		name       string
+		convention rawdate.NullConvention
+		date       rawdate.RawDate
+		want       rawdate.NullRawDate
+		missing    rawdate.RawDate
+	}{
+		{"none", rawdate.NullConvention{}, rawdate.Zero, rawdate.NewNullRawDate(rawdate.Zero), rawdate.Zero},
+		{"zero", rawdate.ZeroIsNull, rawdate.Zero, rawdate.NullRawDate{}, rawdate.Zero},
+		{"zero keeps dates", rawdate.ZeroIsNull, ymd(2024, 1, 1), rawdate.NewNullRawDate(ymd(2024, 1, 1)), rawdate.Zero},
+		{"mssql", rawdate.MSSQLZeroIsNull, ymd(1900, 1, 1), rawdate.NullRawDate{}, rawdate.Zero},
+		{"custom", rawdate.NullConvention{Dates: []rawdate.RawDate{ymd(9999, 12, 31)}}, ymd(9999, 12, 31), rawdate.NullRawDate{}, ymd(9999, 12, 31)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.convention.Nullable(tt.date)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, !tt.want.Valid, tt.convention.IsNull(tt.date))
+			assert.Equal(t, tt.missing, tt.convention.RawDate(rawdate.NullRawDate{}))
+			if got.Valid {
+				assert.Equal(t, tt.date, tt.convention.RawDate(got))
+			} else {
+				assert.True(t, tt.convention.IsNull(tt.convention.RawDate(got)))
+			}
+		})
+	}
+}
+
+func TestDialect_Null(t *testing.T) {
+	dl := rawdate.ISO
+	dl.Null = rawdate.ZeroIsNull
+	v, err := dl.Value(rawdate.Zero)
+	assert.NoError(t, err)
+	assert.Nil(t, v)
+	v, err = dl.NullValuer(rawdate.NullRawDate{}).Value()
+	assert.NoError(t, err)
+	assert.Nil(t, v)
+
+	d, err := dl.FromValue(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, rawdate.Zero, d)
+	_, err = rawdate.ISO.FromValue(nil)
+	assert.ErrorIs(t, err, rawdate.ErrNullDate)
+	assert.ErrorIs(t, d.Scan(nil), rawdate.ErrNullDate)
+
+	var n rawdate.NullRawDate
+	assert.NoError(t, dl.NullScanner(&n).Scan("0001-01-01"))
+	assert.False(t, n.Valid)
+	assert.NoError(t, rawdate.ISO.NullScanner(&n).Scan("0001-01-01"))
+	assert.Equal(t, rawdate.NewNullRawDate(rawdate.Zero), n)
+}
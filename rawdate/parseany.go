@@ -0,0 +1,117 @@
+package rawdate
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrDateFormat is returned by ParseAny when no layout matches
+	ErrDateFormat = errors.New("unknown date format")
+	// ErrAmbiguousDate is matched by the *AmbiguousDateError of ParseAny (for dates like 03/04/2026)
+	ErrAmbiguousDate = errors.New("ambiguous date")
+)
+
+// AmbiguousDateError is returned by ParseAny when layouts result in different dates.
+// It matches ErrAmbiguousDate with errors.Is and carries the candidates, so callers
+// may decide to accept the preferred one.
+type AmbiguousDateError struct {
+	Input  string
+	Layout string    // the first matching layout (which results in Dates[0])
+	Dates  []RawDate // the distinct dates in order of the layouts
+}
+
+func (e *AmbiguousDateError) Error() string {
+	return fmt.Sprintf("%s: %q is %s with layout %q but could also be %s",
+		ErrAmbiguousDate, e.Input, e.Dates[0], e.Layout, e.Dates[1])
+}
+
+func (e *AmbiguousDateError) Unwrap() error {
+	return ErrAmbiguousDate
+}
+
+// DefaultLayouts are the layouts ParseAny tries when none are given. Day before month is
+// preferred, so "03/04/2026" results in an *AmbiguousDateError with 2026-04-03 first.
+var DefaultLayouts = []string{
+	time.DateOnly,     // 2026-10-16
+	"20060102",        // 20261016
+	"2.1.2006",        // 16.10.2026
+	"2006/1/2",        // 2026/10/16
+	"2/1/2006",        // 16/10/2026
+	"1/2/2006",        // 10/16/2026
+	"2-1-2006",        // 16-10-2026
+	"2. January 2006", // 16. Oktober 2026
+	"2. Jan. 2006",    // 16. Okt. 2026
+	"2. Jan 2006",     // 16. Okt 2026
+	"2 January 2006",  // 16 October 2026
+	"2 Jan 2006",      // 16 Oct 2026
+	"January 2, 2006", // October 16, 2026
+	"Jan 2, 2006",     // Oct 16, 2026
+	"Jan. 2, 2006",    // Oct. 16, 2026
+}
+
+// Parser parses dates in several formats, like the ones found in CSV imports
+type Parser struct {
+	Layouts []string // Candidate layouts in order of preference (DefaultLayouts if empty)
+	Locales []Locale // Locales of month and weekday names accepted besides English
+}
+
+// ParseAny parses s with the first matching of the layouts (DefaultLayouts if none are
+// given). Month and weekday names may be English or German. See Parser.Parse.
+func ParseAny(s string, layouts ...string) (RawDate, error) {
+	return Parser{Layouts: layouts, Locales: []Locale{LocaleDE}}.Parse(s)
+}
+
+// Parse parses s with the first matching layout. When other layouts match s with a
+// different date, Zero and an *AmbiguousDateError with the candidates are returned, so
+// callers may decide to accept the first one. If no layout matches the error wraps
+// ErrDateFormat.
+func (p Parser) Parse(s string) (RawDate, error) {
+	layouts := p.Layouts
+	if len(layouts) == 0 {
+		layouts = DefaultLayouts
+	}
+	inputs := []string{strings.TrimSpace(s)}
+	for _, loc := range p.Locales {
+		if in := loc.toEnglish(inputs[0]); in != inputs[0] {
+			inputs = append(inputs, in)
+		}
+	}
+
+	var found []RawDate
+	var first string
+	for _, layout := range layouts {
+		for _, in := range inputs {
+			rd, err := Parse(layout, in)
+			if err != nil {
+				continue
+			}
+			if len(found) == 0 {
+				first = layout
+			}
+			if !containsDate(found, rd) {
+				found = append(found, rd)
+			}
+			break
+		}
+	}
+	switch len(found) {
+	case 0:
+		return Zero, fmt.Errorf("%w: %q", ErrDateFormat, s)
+	case 1:
+		return found[0], nil
+	default:
+		return Zero, &AmbiguousDateError{Input: s, Layout: first, Dates: found}
+	}
+}
+
+func containsDate(dates []RawDate, d RawDate) bool {
+	for _, fd := range dates {
+		if fd == d {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,67 @@
+package rawdate_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/metatexx/avrox/rawdate"
+)
+
+func TestParseAny(t *testing.T) {
+	tests := []struct {
+		in   string
+		want rawdate.RawDate
+	}{
+		{"2026-10-16", ymd(2026, 10, 16)},
+		{"20261016", ymd(2026, 10, 16)},
+		{"16.10.2026", ymd(2026, 10, 16)},
+		{"1.2.2026", ymd(2026, 2, 1)},
+		{" 16/10/2026 ", ymd(2026, 10, 16)},
+		{"10/16/2026", ymd(2026, 10, 16)},
+		{"2026/10/16", ymd(2026, 10, 16)},
+		{"Oct 16, 2026", ymd(2026, 10, 16)},
+		{"October 16, 2026", ymd(2026, 10, 16)},
+		{"16 Oct 2026", ymd(2026, 10, 16)},
+		{"16. Oktober 2026", ymd(2026, 10, 16)},
+		{"16. Okt. 2026", ymd(2026, 10, 16)},
+		{"1. märz 2024", ymd(2024, 3, 1)},
+		{"3. MAI 2024", ymd(2024, 5, 3)},
+		{"4/4/2026", ymd(2026, 4, 4)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := rawdate.ParseAny(tt.in)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseAny_Errors(t *testing.T) {
+	got, err := rawdate.ParseAny("03/04/2026")
+	assert.ErrorIs(t, err, rawdate.ErrAmbiguousDate)
+	assert.Equal(t, rawdate.Zero, got)
+	var ambiguous *rawdate.AmbiguousDateError
+	assert.True(t, errors.As(err, &ambiguous))
+	assert.Equal(t, []rawdate.RawDate{ymd(2026, 4, 3), ymd(2026, 3, 4)}, ambiguous.Dates)
+	assert.Equal(t, "2/1/2006", ambiguous.Layout)
+
+	_, err = rawdate.ParseAny("03/04/2026", "1/2/2006", "2/1/2006")
+	assert.True(t, errors.As(err, &ambiguous))
+	assert.Equal(t, ymd(2026, 3, 4), ambiguous.Dates[0])
+
+	got, err = rawdate.ParseAny("03/04/2026", "1/2/2006")
+	assert.NoError(t, err)
+	assert.Equal(t, ymd(2026, 3, 4), got)
+
+	for _, in := range []string{"", "tomorrow", "31.02.2026", "2026-13-01", "16. Oktober", "16 Foo 2026"} {
+		_, err = rawdate.ParseAny(in)
+		assert.ErrorIs(t, err, rawdate.ErrDateFormat, in)
+	}
+
+	// without German names
+	_, err = rawdate.Parser{}.Parse("16. Oktober 2026")
+	assert.ErrorIs(t, err, rawdate.ErrDateFormat)
+}
@@ -0,0 +1,466 @@
+package rawdate
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Range represents a period of dates from Start to End. End is part of the range
+// unless HalfOpen is set (then End is the first date after the range). A range
+// which contains no date (like [2024-01-01,2024-01-01)) is empty.
+//
+// The text format is the one of the PostgreSQL daterange: "[2024-01-01,2024-01-31]"
+// for a closed and "[2024-01-01,2024-02-01)" for a half-open range.
+type Range struct {
+	Start    RawDate
+	End      RawDate
+	HalfOpen bool
+}
+
+// SplitUnit is the period Range.Split uses for its parts
+type SplitUnit int
+
+const (
+	ByWeek  SplitUnit = iota // ISO weeks (starting on Monday)
+	ByMonth                  // Calendar months
+)
+
+var (
+	_ fmt.Stringer             = Range{}
+	_ encoding.TextMarshaler   = Range{}
+	_ json.Marshaler           = Range{}
+	_ driver.Valuer            = Range{}
+	_ encoding.TextUnmarshaler = (*Range)(nil)
+	_ json.Unmarshaler         = (*Range)(nil)
+	_ sql.Scanner              = (*Range)(nil)
+)
+
+// ErrRangeFormat is returned when parsing a Range (or RangeSet) fails
+var ErrRangeFormat = errors.New("not a date range")
+
+// NewRange creates a closed range which includes start and end.
+func NewRange(start, end RawDate) Range {
+	return Range{Start: start, End: end}
+}
+
+// NewHalfOpenRange creates a range which includes start but not end.
+func NewHalfOpenRange(start, end RawDate) Range {
+	return Range{Start: start, End: end, HalfOpen: true}
+}
+
+// Last returns the last date of the range (which is before Start for an empty range).
+func (r Range) Last() RawDate {
+	if r.HalfOpen {
+		return r.End.AddDate(0, 0, -1)
+	}
+	return r.End
+}
+
+// Closed returns the range with the last date as End.
+func (r Range) Closed() Range {
+	return Range{Start: r.Start, End: r.Last()}
+}
+
+// IsEmpty reports whether the range contains no date.
+func (r Range) IsEmpty() bool {
+	return r.Last().Before(r.Start)
+}
+
+// Days returns the number of dates in the range.
+func (r Range) Days() int {
+	if r.IsEmpty() {
+		return 0
+	}
+	return int(r.Last().Days()-r.Start.Days()) + 1
+}
+
+// Contains reports whether the date is part of the range.
+func (r Range) Contains(d RawDate) bool {
+	return !d.Before(r.Start) && !d.After(r.Last())
+}
+
+// Overlaps reports whether both ranges have at least one date in common.
+func (r Range) Overlaps(o Range) bool {
+	if r.IsEmpty() || o.IsEmpty() {
+		return false
+	}
+	return !r.Start.After(o.Last()) && !o.Start.After(r.Last())
+}
+
+// Intersect returns the dates which are in both ranges. It returns false if the ranges
+// do not overlap. The result has the form (closed or half-open) of r.
+func (r Range) Intersect(o Range) (Range, bool) {
+	if !r.Overlaps(o) {
+		return Range{}, false
+	}
+	return r.withBounds(later(r.Start, o.Start), earlier(r.Last(), o.Last())), true
+}
+
+// Union returns the range covering both ranges. It returns false if the ranges neither
+// overlap nor are adjacent (the union would not be a single range). An empty range
+// does not change the other one. The result has the form (closed or half-open) of r.
+func (r Range) Union(o Range) (Range, bool) {
+	switch {
+	case o.IsEmpty():
+		return r, true
+	case r.IsEmpty():
+		return r.withBounds(o.Start, o.Last()), true
+	case r.Start.After(o.Last().AddDate(0, 0, 1)) || o.Start.After(r.Last().AddDate(0, 0, 1)):
+		return Range{}, false
+	}
+	return r.withBounds(earlier(r.Start, o.Start), later(r.Last(), o.Last())), true
+}
+
+// Split divides the range into the parts which fall into the same week or month.
+// The parts have the form (closed or half-open) of r. An empty range has no parts.
+func (r Range) Split(unit SplitUnit) []Range {
+	var parts []Range
+	last := r.Last()
+	for start := r.Start; !start.After(last); {
+		var end RawDate
+		switch unit {
+		case ByWeek:
+			end = start.NextWeekday(time.Sunday, true)
+		case ByMonth:
+			end = start.MonthEnd()
+		default:
+			end = last
+		}
+		end = earlier(end, last)
+		parts = append(parts, r.withBounds(start, end))
+		start = end.AddDate(0, 0, 1)
+	}
+	return parts
+}
+
+// All returns an iterator over the dates of the range. It matches iter.Seq[RawDate],
+// so it can be used with range-over-func or called with a yield function directly.
+func (r Range) All() func(yield func(RawDate) bool) {
+	return func(yield func(RawDate) bool) {
+		last := r.Last()
+		for d := r.Start; !d.After(last); d = d.AddDate(0, 0, 1) {
+			if !yield(d) {
+				return
+			}
+		}
+	}
+}
+
+// String returns the range in the daterange format.
+func (r Range) String() string {
+	if r.HalfOpen {
+		return "[" + r.Start.String() + "," + r.End.String() + ")"
+	}
+	return "[" + r.Start.String() + "," + r.End.String() + "]"
+}
+
+// ParseRange parses a range in the daterange format. Besides "[" a lower bound of "("
+// (excluding the start date) is also accepted and converted.
+func ParseRange(s string) (Range, error) {
+	s = strings.TrimSpace(s)
+	if len(s) < 2 {
+		return Range{}, ErrRangeFormat
+	}
+	lower, upper := s[0], s[len(s)-1]
+	start, end, found := strings.Cut(s[1:len(s)-1], ",")
+	if !found || (lower != '[' && lower != '(') || (upper != ']' && upper != ')') {
+		return Range{}, ErrRangeFormat
+	}
+	var r Range
+	var err error
+	if r.Start, err = Parse(ISODate, strings.TrimSpace(start)); err != nil {
+		return Range{}, errors.Join(ErrRangeFormat, err)
+	}
+	if r.End, err = Parse(ISODate, strings.TrimSpace(end)); err != nil {
+		return Range{}, errors.Join(ErrRangeFormat, err)
+	}
+	if lower == '(' {
+		r.Start = r.Start.AddDate(0, 0, 1)
+	}
+	r.HalfOpen = upper == ')'
+	return r, nil
+}
+
+// MarshalText implements the encoding.TextMarshaler
+func (r Range) MarshalText() ([]byte, error) {
+	return []byte(r.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler
+func (r *Range) UnmarshalText(data []byte) error {
+	parsed, err := ParseRange(string(data))
+	if err != nil {
+		return err
+	}
+	*r = parsed
+	return nil
+}
+
+// MarshalJSON implements `json.Marshaler` using the daterange format as string
+func (r Range) MarshalJSON() ([]byte, error) {
+	return json.Marshal(r.String())
+}
+
+// UnmarshalJSON implements `json.Unmarshaler`
+func (r *Range) UnmarshalJSON(data []byte) error {
+	s := ""
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	return r.UnmarshalText([]byte(s))
+}
+
+// Value implements `driver.Valuer`; marshals to the daterange format (which PostgreSQL
+// takes for daterange columns, other databases store it as text)
+func (r Range) Value() (driver.Value, error) {
+	return r.String(), nil
+}
+
+// Scan implements `sql.Scanner` for the daterange format
+func (r *Range) Scan(src any) error {
+	switch st := src.(type) {
+	case string:
+		return r.UnmarshalText([]byte(st))
+	case []byte:
+		return r.UnmarshalText(st)
+	default:
+		return fmt.Errorf("wrong type to scan as Range: type=%T", src)
+	}
+}
+
+// withBounds returns the range from start to last in the form of r
+func (r Range) withBounds(start, last RawDate) Range {
+	if r.HalfOpen {
+		return NewHalfOpenRange(start, last.AddDate(0, 0, 1))
+	}
+	return NewRange(start, last)
+}
+
+func earlier(a, b RawDate) RawDate {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
+
+func later(a, b RawDate) RawDate {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
+
+// RangeSet is a normalized set of dates stored as sorted closed ranges, which neither
+// overlap nor are adjacent. The zero value is an empty set.
+//
+// The text format is the one of the PostgreSQL datemultirange, using closed ranges:
+// "{[2024-01-01,2024-01-31],[2024-03-01,2024-03-31]}".
+type RangeSet struct {
+	ranges []Range
+}
+
+var (
+	_ fmt.Stringer             = RangeSet{}
+	_ encoding.TextMarshaler   = RangeSet{}
+	_ json.Marshaler           = RangeSet{}
+	_ driver.Valuer            = RangeSet{}
+	_ encoding.TextUnmarshaler = (*RangeSet)(nil)
+	_ json.Unmarshaler         = (*RangeSet)(nil)
+	_ sql.Scanner              = (*RangeSet)(nil)
+)
+
+// NewRangeSet creates the set of all dates in the ranges.
+func NewRangeSet(ranges ...Range) RangeSet {
+	var s RangeSet
+	s.Add(ranges...)
+	return s
+}
+
+// Add adds the dates of the ranges to the set and merges overlapping and adjacent ranges.
+func (s *RangeSet) Add(ranges ...Range) {
+	all := make([]Range, 0, len(s.ranges)+len(ranges))
+	all = append(all, s.ranges...)
+	for _, r := range ranges {
+		if !r.IsEmpty() {
+			all = append(all, r.Closed())
+		}
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].Start.Before(all[j].Start) })
+	merged := all[:0]
+	for _, r := range all {
+		if n := len(merged); n > 0 {
+			if u, ok := merged[n-1].Union(r); ok {
+				merged[n-1] = u
+				continue
+			}
+		}
+		merged = append(merged, r)
+	}
+	s.ranges = merged
+}
+
+// Ranges returns a copy of the (closed) ranges of the set.
+func (s RangeSet) Ranges() []Range {
+	return append([]Range(nil), s.ranges...)
+}
+
+// IsEmpty reports whether the set contains no date.
+func (s RangeSet) IsEmpty() bool {
+	return len(s.ranges) == 0
+}
+
+// Days returns the number of dates in the set.
+func (s RangeSet) Days() int {
+	days := 0
+	for _, r := range s.ranges {
+		days += r.Days()
+	}
+	return days
+}
+
+// Contains reports whether the date is part of the set.
+func (s RangeSet) Contains(d RawDate) bool {
+	i := sort.Search(len(s.ranges), func(i int) bool { return !s.ranges[i].End.Before(d) })
+	return i < len(s.ranges) && s.ranges[i].Contains(d)
+}
+
+// Overlaps reports whether the range has at least one date in common with the set.
+func (s RangeSet) Overlaps(r Range) bool {
+	for _, sr := range s.ranges {
+		if sr.Overlaps(r) {
+			return true
+		}
+	}
+	return false
+}
+
+// Union returns the set of dates which are in either set.
+func (s RangeSet) Union(o RangeSet) RangeSet {
+	return NewRangeSet(append(s.Ranges(), o.ranges...)...)
+}
+
+// Intersect returns the set of dates which are in both sets.
+func (s RangeSet) Intersect(o RangeSet) RangeSet {
+	var result RangeSet
+	for i, j := 0, 0; i < len(s.ranges) && j < len(o.ranges); {
+		if r, ok := s.ranges[i].Intersect(o.ranges[j]); ok {
+			result.ranges = append(result.ranges, r)
+		}
+		if s.ranges[i].End.Before(o.ranges[j].End) {
+			i++
+		} else {
+			j++
+		}
+	}
+	return result
+}
+
+// All returns an iterator over the dates of the set. It matches iter.Seq[RawDate],
+// so it can be used with range-over-func or called with a yield function directly.
+func (s RangeSet) All() func(yield func(RawDate) bool) {
+	return func(yield func(RawDate) bool) {
+		for _, r := range s.ranges {
+			stopped := false
+			r.All()(func(d RawDate) bool {
+				stopped = !yield(d)
+				return !stopped
+			})
+			if stopped {
+				return
+			}
+		}
+	}
+}
+
+// String returns the set in the datemultirange format.
+func (s RangeSet) String() string {
+	parts := make([]string, len(s.ranges))
+	for i, r := range s.ranges {
+		parts[i] = r.String()
+	}
+	return "{" + strings.Join(parts, ",") + "}"
+}
+
+// ParseRangeSet parses a set in the datemultirange format (the ranges can be closed or
+// half-open and do not need to be normalized).
+func ParseRangeSet(s string) (RangeSet, error) {
+	s = strings.TrimSpace(s)
+	if len(s) < 2 || s[0] != '{' || s[len(s)-1] != '}' {
+		return RangeSet{}, ErrRangeFormat
+	}
+	var ranges []Range
+	rest := strings.TrimSpace(s[1 : len(s)-1])
+	for rest != "" {
+		end := strings.IndexAny(rest, "])")
+		if end < 0 {
+			return RangeSet{}, ErrRangeFormat
+		}
+		r, err := ParseRange(rest[:end+1])
+		if err != nil {
+			return RangeSet{}, err
+		}
+		ranges = append(ranges, r)
+		rest = strings.TrimSpace(rest[end+1:])
+		if rest != "" {
+			if rest[0] != ',' {
+				return RangeSet{}, ErrRangeFormat
+			}
+			rest = strings.TrimSpace(rest[1:])
+		}
+	}
+	return NewRangeSet(ranges...), nil
+}
+
+// MarshalText implements the encoding.TextMarshaler
+func (s RangeSet) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler
+func (s *RangeSet) UnmarshalText(data []byte) error {
+	parsed, err := ParseRangeSet(string(data))
+	if err != nil {
+		return err
+	}
+	*s = parsed
+	return nil
+}
+
+// MarshalJSON implements `json.Marshaler` as array of ranges
+func (s RangeSet) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.Ranges())
+}
+
+// UnmarshalJSON implements `json.Unmarshaler` for an array of ranges
+func (s *RangeSet) UnmarshalJSON(data []byte) error {
+	var ranges []Range
+	if err := json.Unmarshal(data, &ranges); err != nil {
+		return err
+	}
+	*s = NewRangeSet(ranges...)
+	return nil
+}
+
+// Value implements `driver.Valuer`; marshals to the datemultirange format
+func (s RangeSet) Value() (driver.Value, error) {
+	return s.String(), nil
+}
+
+// Scan implements `sql.Scanner` for the datemultirange format
+func (s *RangeSet) Scan(src any) error {
+	switch st := src.(type) {
+	case string:
+		return s.UnmarshalText([]byte(st))
+	case []byte:
+		return s.UnmarshalText(st)
+	default:
+		return fmt.Errorf("wrong type to scan as RangeSet: type=%T", src)
+	}
+}
@@ -0,0 +1,275 @@
+package rawdate_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/metatexx/avrox/rawdate"
+)
+
+func ymd(y int, m time.Month, day int) rawdate.RawDate {
+	return rawdate.MustNew(y, m, day)
+}
+
+func TestRange_Days(t *testing.T) {
+	tests := []struct {
+		name  string
+		r     rawdate.Range
+		want  int
+		empty bool
+	}{
+		{"closed", rawdate.NewRange(ymd(2024, 1, 1), ymd(2024, 1, 31)), 31, false},
+		{"half-open", rawdate.NewHalfOpenRange(ymd(2024, 1, 1), ymd(2024, 2, 1)), 31, false},
+		{"single day", rawdate.NewRange(ymd(2024, 2, 29), ymd(2024, 2, 29)), 1, false},
+		{"empty half-open", rawdate.NewHalfOpenRange(ymd(2024, 1, 1), ymd(2024, 1, 1)), 0, true},
+		{"reversed", rawdate.NewRange(ymd(2024, 1, 2), ymd(2024, 1, 1)), 0, true},
+		{"leap year", rawdate.NewRange(ymd(2024, 1, 1), ymd(2024, 12, 31)), 366, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.r.Days())
+			assert.Equal(t, tt.empty, tt.r.IsEmpty())
+			count := 0
+			tt.r.All()(func(rawdate.RawDate) bool {
+				count++
+				return true
+			})
+			assert.Equal(t, tt.want, count)
+		})
+	}
+}
+
+func TestRange_Contains(t *testing.T) {
+	closed := rawdate.NewRange(ymd(2024, 1, 1), ymd(2024, 1, 31))
+	halfOpen := rawdate.NewHalfOpenRange(ymd(2024, 1, 1), ymd(2024, 1, 31))
+	tests := []struct {
+		name string
+		r    rawdate.Range
+		date rawdate.RawDate
+		want bool
+	}{
+		{"closed start", closed, ymd(2024, 1, 1), true},
+		{"closed end", closed, ymd(2024, 1, 31), true},
+		{"closed before", closed, ymd(2023, 12, 31), false},
+		{"closed after", closed, ymd(2024, 2, 1), false},
+		{"half-open start", halfOpen, ymd(2024, 1, 1), true},
+		{"half-open end", halfOpen, ymd(2024, 1, 31), false},
+		{"half-open last", halfOpen, ymd(2024, 1, 30), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.r.Contains(tt.date))
+		})
+	}
+}
+
+func TestRange_IntersectUnion(t *testing.T) {
+	tests := []struct {
+		name      string
+		a, b      rawdate.Range
+		overlaps  bool
+		intersect rawdate.Range
+		union     rawdate.Range
+		unionOK   bool
+	}{
+		{
+			name:      "overlapping",
+			a:         rawdate.NewRange(ymd(2024, 1, 1), ymd(2024, 1, 20)),
+			b:         rawdate.NewRange(ymd(2024, 1, 10), ymd(2024, 1, 31)),
+			overlaps:  true,
+			intersect: rawdate.NewRange(ymd(2024, 1, 10), ymd(2024, 1, 20)),
+			union:     rawdate.NewRange(ymd(2024, 1, 1), ymd(2024, 1, 31)),
+			unionOK:   true,
+		},
+		{
+			name:    "adjacent",
+			a:       rawdate.NewRange(ymd(2024, 1, 1), ymd(2024, 1, 31)),
+			b:       rawdate.NewRange(ymd(2024, 2, 1), ymd(2024, 2, 29)),
+			union:   rawdate.NewRange(ymd(2024, 1, 1), ymd(2024, 2, 29)),
+			unionOK: true,
+		},
+		{
+			name:    "half-open adjacent",
+			a:       rawdate.NewHalfOpenRange(ymd(2024, 1, 1), ymd(2024, 2, 1)),
+			b:       rawdate.NewHalfOpenRange(ymd(2024, 2, 1), ymd(2024, 3, 1)),
+			union:   rawdate.NewHalfOpenRange(ymd(2024, 1, 1), ymd(2024, 3, 1)),
+			unionOK: true,
+		},
+		{
+			name:      "mixed forms",
+			a:         rawdate.NewHalfOpenRange(ymd(2024, 1, 1), ymd(2024, 2, 1)),
+			b:         rawdate.NewRange(ymd(2024, 1, 31), ymd(2024, 2, 5)),
+			overlaps:  true,
+			intersect: rawdate.NewHalfOpenRange(ymd(2024, 1, 31), ymd(2024, 2, 1)),
+			union:     rawdate.NewHalfOpenRange(ymd(2024, 1, 1), ymd(2024, 2, 6)),
+			unionOK:   true,
+		},
+		{
+			name: "gap",
+			a:    rawdate.NewRange(ymd(2024, 1, 1), ymd(2024, 1, 30)),
+			b:    rawdate.NewRange(ymd(2024, 2, 1), ymd(2024, 2, 29)),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.overlaps, tt.a.Overlaps(tt.b))
+			assert.Equal(t, tt.overlaps, tt.b.Overlaps(tt.a))
+			intersect, ok := tt.a.Intersect(tt.b)
+			assert.Equal(t, tt.overlaps, ok)
+			assert.Equal(t, tt.intersect, intersect)
+			union, ok := tt.a.Union(tt.b)
+			assert.Equal(t, tt.unionOK, ok)
+			assert.Equal(t, tt.union, union)
+		})
+	}
+}
+
+func TestRange_Split(t *testing.T) {
+	tests := []struct {
+		name string
+		r    rawdate.Range
+		unit rawdate.SplitUnit
+		want []rawdate.Range
+	}{
+		{
+			name: "by month",
+			r:    rawdate.NewRange(ymd(2024, 1, 15), ymd(2024, 3, 10)),
+			unit: rawdate.ByMonth,
+			want: []rawdate.Range{
+				rawdate.NewRange(ymd(2024, 1, 15), ymd(2024, 1, 31)),
+				rawdate.NewRange(ymd(2024, 2, 1), ymd(2024, 2, 29)),
+				rawdate.NewRange(ymd(2024, 3, 1), ymd(2024, 3, 10)),
+			},
+		},
+		{
+			name: "by month half-open",
+			r:    rawdate.NewHalfOpenRange(ymd(2024, 1, 15), ymd(2024, 3, 1)),
+			unit: rawdate.ByMonth,
+			want: []rawdate.Range{
+				rawdate.NewHalfOpenRange(ymd(2024, 1, 15), ymd(2024, 2, 1)),
+				rawdate.NewHalfOpenRange(ymd(2024, 2, 1), ymd(2024, 3, 1)),
+			},
+		},
+		{
+			name: "by week",
+			r:    rawdate.NewRange(ymd(2024, 1, 3), ymd(2024, 1, 16)), // Wednesday to Tuesday
+			unit: rawdate.ByWeek,
+			want: []rawdate.Range{
+				rawdate.NewRange(ymd(2024, 1, 3), ymd(2024, 1, 7)),
+				rawdate.NewRange(ymd(2024, 1, 8), ymd(2024, 1, 14)),
+				rawdate.NewRange(ymd(2024, 1, 15), ymd(2024, 1, 16)),
+			},
+		},
+		{
+			name: "empty",
+			r:    rawdate.NewHalfOpenRange(ymd(2024, 1, 3), ymd(2024, 1, 3)),
+			unit: rawdate.ByWeek,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.r.Split(tt.unit))
+		})
+	}
+}
+
+func TestRange_Text(t *testing.T) {
+	tests := []struct {
+		input string
+		want  rawdate.Range
+		text  string
+		err   bool
+	}{
+		{input: "[2024-01-01,2024-01-31]", want: rawdate.NewRange(ymd(2024, 1, 1), ymd(2024, 1, 31)), text: "[2024-01-01,2024-01-31]"},
+		{input: "[2024-01-01,2024-02-01)", want: rawdate.NewHalfOpenRange(ymd(2024, 1, 1), ymd(2024, 2, 1)), text: "[2024-01-01,2024-02-01)"},
+		{input: " (2023-12-31, 2024-02-01) ", want: rawdate.NewHalfOpenRange(ymd(2024, 1, 1), ymd(2024, 2, 1)), text: "[2024-01-01,2024-02-01)"},
+		{input: "2024-01-01/2024-01-31", err: true},
+		{input: "[2024-01-01,2024-02-30]", err: true},
+		{input: "[]", err: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			var r rawdate.Range
+			err := r.UnmarshalText([]byte(tt.input))
+			if tt.err {
+				assert.ErrorIs(t, err, rawdate.ErrRangeFormat)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, r)
+			assert.Equal(t, tt.text, r.String())
+
+			data, err := json.Marshal(r)
+			assert.NoError(t, err)
+			var fromJSON rawdate.Range
+			assert.NoError(t, json.Unmarshal(data, &fromJSON))
+			assert.Equal(t, tt.want, fromJSON)
+
+			value, err := r.Value()
+			assert.NoError(t, err)
+			var scanned rawdate.Range
+			assert.NoError(t, scanned.Scan([]byte(value.(string))))
+			assert.Equal(t, tt.want, scanned)
+		})
+	}
+}
+
+func TestRangeSet(t *testing.T) {
+	s := rawdate.NewRangeSet(
+		rawdate.NewRange(ymd(2024, 3, 1), ymd(2024, 3, 31)),
+		rawdate.NewHalfOpenRange(ymd(2024, 1, 1), ymd(2024, 1, 15)),
+		rawdate.NewRange(ymd(2024, 1, 10), ymd(2024, 1, 20)),
+		rawdate.NewRange(ymd(2024, 1, 21), ymd(2024, 1, 25)),       // adjacent
+		rawdate.NewHalfOpenRange(ymd(2024, 2, 1), ymd(2024, 2, 1)), // empty
+	)
+	assert.Equal(t, []rawdate.Range{
+		rawdate.NewRange(ymd(2024, 1, 1), ymd(2024, 1, 25)),
+		rawdate.NewRange(ymd(2024, 3, 1), ymd(2024, 3, 31)),
+	}, s.Ranges())
+	assert.Equal(t, 25+31, s.Days())
+	assert.Equal(t, "{[2024-01-01,2024-01-25],[2024-03-01,2024-03-31]}", s.String())
+	assert.True(t, s.Contains(ymd(2024, 1, 25)))
+	assert.False(t, s.Contains(ymd(2024, 2, 15)))
+	assert.True(t, s.Contains(ymd(2024, 3, 1)))
+	assert.False(t, s.Contains(ymd(2024, 4, 1)))
+	assert.True(t, s.Overlaps(rawdate.NewRange(ymd(2024, 2, 1), ymd(2024, 3, 1))))
+	assert.False(t, s.Overlaps(rawdate.NewRange(ymd(2024, 2, 1), ymd(2024, 2, 29))))
+
+	o := rawdate.NewRangeSet(rawdate.NewRange(ymd(2024, 1, 20), ymd(2024, 3, 5)))
+	assert.Equal(t, "{[2024-01-20,2024-01-25],[2024-03-01,2024-03-05]}", s.Intersect(o).String())
+	assert.Equal(t, "{[2024-01-01,2024-03-31]}", s.Union(o).String())
+	assert.True(t, s.Intersect(rawdate.RangeSet{}).IsEmpty())
+
+	var dates []rawdate.RawDate
+	s.All()(func(date rawdate.RawDate) bool {
+		dates = append(dates, date)
+		return len(dates) < 27
+	})
+	assert.Equal(t, ymd(2024, 1, 1), dates[0])
+	assert.Equal(t, ymd(2024, 3, 2), dates[26])
+
+	parsed, err := rawdate.ParseRangeSet("{[2024-03-01,2024-04-01),[2024-01-01,2024-01-25]}")
+	assert.NoError(t, err)
+	assert.Equal(t, s, parsed)
+	parsed, err = rawdate.ParseRangeSet("{}")
+	assert.NoError(t, err)
+	assert.True(t, parsed.IsEmpty())
+	_, err = rawdate.ParseRangeSet("{[2024-03-01,2024-04-01];[2024-01-01,2024-01-25]}")
+	assert.ErrorIs(t, err, rawdate.ErrRangeFormat)
+
+	data, err := json.Marshal(s)
+	assert.NoError(t, err)
+	assert.Equal(t, `["[2024-01-01,2024-01-25]","[2024-03-01,2024-03-31]"]`, string(data))
+	var fromJSON rawdate.RangeSet
+	assert.NoError(t, json.Unmarshal(data, &fromJSON))
+	assert.Equal(t, s, fromJSON)
+
+	value, err := s.Value()
+	assert.NoError(t, err)
+	var scanned rawdate.RangeSet
+	assert.NoError(t, scanned.Scan(value))
+	assert.Equal(t, s, scanned)
+}
@@ -48,10 +48,10 @@ func MustNew(y int, m time.Month, d int) RawDate {
 	return r
 }
 
-// Today creates a new RawDate with the current date (in local time)
+// Today creates a new RawDate with the current date (in local time) of the package
+// clock (see SetClock). Use TodayIn for the date in another location.
 func Today() RawDate {
-	t := time.Now()
-	return RawDate{Year0: t.Year() - 1, Month0: int8(t.Month() - 1), Day0: int8(t.Day() - 1)}
+	return TodayIn(time.Local)
 }
 
 // Day returns the day of the month for the RawDate.
@@ -457,3 +457,28 @@ func TestRawDate_PreviousWeekday(t *testing.T) {
 		})
 	}
 }
+
+func TestRawDate_Days(t *testing.T) {
+	tests := []struct {
+		name string
+		date rawdate.RawDate
+		want rawdate.Days
+	}{
+		{"epoch", rawdate.MustNew(1970, 1, 1), 0},
+		{"after epoch", rawdate.MustNew(2024, 2, 29), 19782},
+		{"before epoch", rawdate.MustNew(1969, 12, 31), -1},
+		{"far before epoch", rawdate.MustNew(1900, 3, 1), -25508},
+		{"zero", rawdate.Zero, -719162},
+		{"max", rawdate.MustNew(9999, 12, 31), 2932896},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.date.Days(); got != tt.want {
+				t.Errorf("Days() = %v, want %v", got, tt.want)
+			}
+			if got := rawdate.FromDays(tt.want); got != tt.date {
+				t.Errorf("FromDays() = %v, want %v", got, tt.date)
+			}
+		})
+	}
+}
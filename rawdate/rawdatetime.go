@@ -0,0 +1,263 @@
+package rawdate
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// RawDateTime represents a floating local date and time without a time zone, like the
+// start of a meeting which happens at 09:00 wherever the attendees are.
+// The zero value is 0001-01-01 00:00:00.
+//
+// For the avro `local-timestamp-millis` and `local-timestamp-micros` logical types use the
+// LocalMillis and LocalMicros types.
+type RawDateTime struct {
+	Date  RawDate
+	Clock RawTime
+}
+
+var (
+	_ fmt.Stringer             = RawDateTime{}
+	_ encoding.TextMarshaler   = RawDateTime{}
+	_ json.Marshaler           = RawDateTime{}
+	_ fmt.GoStringer           = RawDateTime{}
+	_ driver.Valuer            = RawDateTime{}
+	_ encoding.TextUnmarshaler = (*RawDateTime)(nil)
+	_ json.Unmarshaler         = (*RawDateTime)(nil)
+	_ sql.Scanner              = (*RawDateTime)(nil)
+)
+
+// rawDateTimeLayouts are the layouts accepted when unmarshalling a RawDateTime. Zones (like
+// the ones of the SQLite driver) are ignored, the wall clock is used.
+var rawDateTimeLayouts = []string{
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04:05Z07:00",
+	"2006-01-02 15:04:05Z07:00",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04",
+}
+
+// NewDateTime creates a new RawDateTime given the date and the time of day.
+// Returns an error if the provided values do not form a valid date and time.
+func NewDateTime(y int, m time.Month, d, hour, minute, sec, nsec int) (RawDateTime, error) {
+	date, err := New(y, m, d)
+	if err != nil {
+		return RawDateTime{}, err
+	}
+	clock, err := NewTime(hour, minute, sec, nsec)
+	if err != nil {
+		return RawDateTime{}, err
+	}
+	return RawDateTime{Date: date, Clock: clock}, nil
+}
+
+// MustNewDateTime creates a new RawDateTime given the date and the time of day.
+// Panics if the provided values do not form a valid date and time.
+func MustNewDateTime(y int, m time.Month, d, hour, minute, sec, nsec int) RawDateTime {
+	dt, err := NewDateTime(y, m, d, hour, minute, sec, nsec)
+	if err != nil {
+		panic(err)
+	}
+	return dt
+}
+
+// DateTimeOf returns the wall clock date and time of t (in its location)
+func DateTimeOf(t time.Time) RawDateTime {
+	return RawDateTime{Date: FromTime(t), Clock: TimeOf(t)}
+}
+
+// ParseDateTime parses a string representing a date and time with the layout.
+// Zone information of the layout is ignored.
+func ParseDateTime(layout, s string) (RawDateTime, error) {
+	t, err := time.Parse(layout, s)
+	if err != nil {
+		return RawDateTime{}, err
+	}
+	return DateTimeOf(t), nil
+}
+
+// IsValid checks whether the RawDateTime represents a valid date and time.
+func (dt RawDateTime) IsValid() bool {
+	return dt.Date.IsValid() && dt.Clock.IsValid()
+}
+
+// IsZero checks if the RawDateTime is 0001-01-01 00:00:00.
+func (dt RawDateTime) IsZero() bool {
+	return dt.Date.IsZero() && dt.Clock.IsZero()
+}
+
+// Time returns the time.Time of the wall clock in the location. Like time.Date it
+// normalizes wall clock times which do not exist in the location (like in DST gaps).
+func (dt RawDateTime) Time(location *time.Location) time.Time {
+	return dt.Clock.On(dt.Date, location)
+}
+
+// Add returns the RawDateTime with the duration added to the wall clock
+func (dt RawDateTime) Add(d time.Duration) RawDateTime {
+	return DateTimeOf(dt.Time(time.UTC).Add(d))
+}
+
+// AddDate adds the specified number of years, months, and days to the date (like
+// RawDate.AddDate) and keeps the time of day.
+func (dt RawDateTime) AddDate(years, months, days int) RawDateTime {
+	return RawDateTime{Date: dt.Date.AddDate(years, months, days), Clock: dt.Clock}
+}
+
+// Sub returns the wall clock duration dt-u (which ignores DST changes in between)
+func (dt RawDateTime) Sub(u RawDateTime) time.Duration {
+	return dt.Time(time.UTC).Sub(u.Time(time.UTC))
+}
+
+// Compare compares the RawDateTime with another RawDateTime.
+// Returns 1 if dt > u, -1 if dt < u, and 0 if dt == u.
+func (dt RawDateTime) Compare(u RawDateTime) int {
+	if c := Compare(dt.Date, u.Date); c != 0 {
+		return c
+	}
+	return dt.Clock.Compare(u.Clock)
+}
+
+// Equal reports whether the RawDateTime dt is equal RawDateTime u.
+func (dt RawDateTime) Equal(u RawDateTime) bool {
+	return dt.Compare(u) == 0
+}
+
+// After reports whether the RawDateTime dt is after RawDateTime u.
+func (dt RawDateTime) After(u RawDateTime) bool {
+	return dt.Compare(u) > 0
+}
+
+// Before reports whether the RawDateTime dt is before RawDateTime u.
+func (dt RawDateTime) Before(u RawDateTime) bool {
+	return dt.Compare(u) < 0
+}
+
+// Format formats the RawDateTime according to the provided layout string.
+func (dt RawDateTime) Format(format string) string {
+	return dt.Time(time.UTC).Format(format)
+}
+
+// String returns a string representation of the RawDateTime in YYYY-MM-DDTHH:MM:SS format
+// (with fractional seconds if there are any).
+func (dt RawDateTime) String() string {
+	return dt.Format("2006-01-02T15:04:05.999999999")
+}
+
+// GoString implements `fmt.GoStringer`.
+func (dt RawDateTime) GoString() string {
+	return fmt.Sprintf("rawdate.MustNewDateTime(%d, time.%s, %d, %d, %d, %d, %d)",
+		dt.Date.Year(), dt.Date.Month(), dt.Date.Day(), dt.Clock.Hour, dt.Clock.Minute, dt.Clock.Second, dt.Clock.Nanosecond)
+}
+
+// MarshalText implements the encoding.TextMarshaler
+func (dt RawDateTime) MarshalText() ([]byte, error) {
+	return []byte(dt.String()), nil
+}
+
+// MarshalJSON implements `json.Marshaler` using YYYY-MM-DDTHH:MM:SS
+func (dt RawDateTime) MarshalJSON() ([]byte, error) {
+	return json.Marshal(dt.String())
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler for YYYY-MM-DDTHH:MM[:SS[.fraction]]
+// (or with a space instead of the T)
+func (dt *RawDateTime) UnmarshalText(data []byte) error {
+	var err error
+	for _, layout := range rawDateTimeLayouts {
+		var parsed RawDateTime
+		if parsed, err = ParseDateTime(layout, string(data)); err == nil {
+			*dt = parsed
+			return nil
+		}
+	}
+	return err
+}
+
+// UnmarshalJSON implements `json.Unmarshaler` for YYYY-MM-DDTHH:MM[:SS[.fraction]]
+func (dt *RawDateTime) UnmarshalJSON(data []byte) error {
+	s := ""
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	return dt.UnmarshalText([]byte(s))
+}
+
+// Value implements `driver.Valuer`; marshals to a YYYY-MM-DD HH:MM:SS string which
+// databases accept for their datetime (timestamp without time zone) columns
+func (dt RawDateTime) Value() (driver.Value, error) {
+	return dt.Format("2006-01-02 15:04:05.999999999"), nil
+}
+
+// Scan implements `sql.Scanner` by unmarshalling from `time.Time` (its wall clock) or a string.
+// NULL is an error, scan into a **RawDateTime for nullable columns.
+func (dt *RawDateTime) Scan(src any) error {
+	switch st := src.(type) {
+	case string:
+		return dt.UnmarshalText([]byte(st))
+	case []byte:
+		return dt.UnmarshalText(st)
+	case time.Time:
+		*dt = DateTimeOf(st)
+		return nil
+	default:
+		return fmt.Errorf("wrong type to scan as RawDateTime: type=%T", src)
+	}
+}
+
+// LocalMillis is a wall clock date and time as milliseconds since 1970-01-01 00:00:00.
+// It is the value of the avro `local-timestamp-millis` logical type, so struct fields of
+// this type can be declared as {"type":"long","logicalType":"local-timestamp-millis"}.
+type LocalMillis int64
+
+// LocalMicros is a wall clock date and time as microseconds since 1970-01-01 00:00:00.
+// It is the value of the avro `local-timestamp-micros` logical type, so struct fields of
+// this type can be declared as {"type":"long","logicalType":"local-timestamp-micros"}.
+type LocalMicros int64
+
+// LocalMillis returns the milliseconds since 1970-01-01 00:00:00 (truncating the nanoseconds)
+func (dt RawDateTime) LocalMillis() LocalMillis {
+	return LocalMillis(int64(dt.Date.Days())*86_400_000 + dt.Clock.Duration().Milliseconds())
+}
+
+// LocalMicros returns the microseconds since 1970-01-01 00:00:00 (truncating the nanoseconds)
+func (dt RawDateTime) LocalMicros() LocalMicros {
+	return LocalMicros(int64(dt.Date.Days())*86_400_000_000 + dt.Clock.Duration().Microseconds())
+}
+
+// RawDateTime returns the date and time
+func (ms LocalMillis) RawDateTime() RawDateTime {
+	return fromLocal(int64(ms), 86_400_000, time.Millisecond)
+}
+
+// String returns the date and time in YYYY-MM-DDTHH:MM:SS format
+func (ms LocalMillis) String() string {
+	return ms.RawDateTime().String()
+}
+
+// RawDateTime returns the date and time
+func (us LocalMicros) RawDateTime() RawDateTime {
+	return fromLocal(int64(us), 86_400_000_000, time.Microsecond)
+}
+
+// String returns the date and time in YYYY-MM-DDTHH:MM:SS format
+func (us LocalMicros) String() string {
+	return us.RawDateTime().String()
+}
+
+func fromLocal(v, perDay int64, unit time.Duration) RawDateTime {
+	days := floorDiv64(v, perDay)
+	return RawDateTime{Date: FromDays(Days(days)), Clock: TimeFromDuration(time.Duration(v-days*perDay) * unit)}
+}
+
+func floorDiv64(a, b int64) int64 {
+	q := a / b
+	if (a%b != 0) && ((a < 0) != (b < 0)) {
+		q--
+	}
+	return q
+}
@@ -0,0 +1,114 @@
+package rawdate_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/metatexx/avrox/rawdate"
+)
+
+func TestRawDateTime_Text(t *testing.T) {
+	tests := []struct {
+		in   string
+		want rawdate.RawDateTime
+		out  string
+	}{
+		{"2024-03-31T02:30:00", rawdate.MustNewDateTime(2024, 3, 31, 2, 30, 0, 0), "2024-03-31T02:30:00"},
+		{"2024-03-31 02:30", rawdate.MustNewDateTime(2024, 3, 31, 2, 30, 0, 0), "2024-03-31T02:30:00"},
+		{"2024-03-31T02:30:00.123456+01:00", rawdate.MustNewDateTime(2024, 3, 31, 2, 30, 0, 123_456_000), "2024-03-31T02:30:00.123456"},
+		{"0001-01-01T00:00:00", rawdate.RawDateTime{}, "0001-01-01T00:00:00"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			var dt rawdate.RawDateTime
+			assert.NoError(t, dt.UnmarshalText([]byte(tt.in)))
+			assert.Equal(t, tt.want, dt)
+			assert.Equal(t, tt.out, dt.String())
+
+			data, err := json.Marshal(dt)
+			assert.NoError(t, err)
+			var back rawdate.RawDateTime
+			assert.NoError(t, json.Unmarshal(data, &back))
+			assert.Equal(t, dt, back)
+		})
+	}
+	var dt rawdate.RawDateTime
+	for _, in := range []string{"", "2024-03-31", "2024-02-30T10:00:00", "10:00"} {
+		assert.Error(t, dt.UnmarshalText([]byte(in)), in)
+	}
+	assert.Equal(t, "rawdate.MustNewDateTime(2024, time.March, 31, 2, 30, 0, 0)",
+		fmt.Sprintf("%#v", rawdate.MustNewDateTime(2024, 3, 31, 2, 30, 0, 0)))
+	_, err := rawdate.NewDateTime(2024, 2, 30, 0, 0, 0, 0)
+	assert.Error(t, err)
+	_, err = rawdate.NewDateTime(2024, 2, 3, 24, 0, 0, 0)
+	assert.Error(t, err)
+}
+
+func TestRawDateTime_Time(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skip("no time zone database")
+	}
+	dt := ymd(2024, 10, 27).At(rawdate.MustNewTime(9, 0, 0, 0))
+	tm := dt.Time(berlin)
+	assert.Equal(t, "2024-10-27T09:00:00+01:00", tm.Format(time.RFC3339))
+	assert.Equal(t, dt, rawdate.DateTimeOf(tm))
+	assert.Equal(t, dt, rawdate.DateTimeOf(tm.In(time.UTC)).Add(time.Hour))
+
+	// wall clock arithmetic ignores the DST change
+	before := ymd(2024, 10, 26).At(rawdate.MustNewTime(9, 0, 0, 0))
+	assert.Equal(t, 24*time.Hour, dt.Sub(before))
+	assert.Equal(t, 25*time.Hour, dt.Time(berlin).Sub(before.Time(berlin)))
+	assert.Equal(t, before, dt.AddDate(0, 0, -1))
+	assert.Equal(t, ymd(2024, 10, 28).At(rawdate.MustNewTime(1, 0, 0, 0)), dt.Add(16*time.Hour))
+	assert.True(t, before.Before(dt))
+	assert.True(t, dt.After(before))
+	assert.Equal(t, 0, dt.Compare(dt))
+	assert.True(t, rawdate.RawDateTime{}.IsZero())
+}
+
+func TestRawDateTime_Local(t *testing.T) {
+	tests := []rawdate.RawDateTime{
+		rawdate.MustNewDateTime(1970, 1, 1, 0, 0, 0, 0),
+		rawdate.MustNewDateTime(2024, 2, 29, 23, 59, 59, 999_000_000),
+		rawdate.MustNewDateTime(1969, 12, 31, 23, 59, 59, 999_000_000),
+		rawdate.MustNewDateTime(1, 1, 1, 0, 0, 0, 0),
+		rawdate.MustNewDateTime(9999, 12, 31, 23, 59, 59, 999_000_000),
+	}
+	for _, dt := range tests {
+		t.Run(dt.String(), func(t *testing.T) {
+			assert.Equal(t, dt, dt.LocalMicros().RawDateTime())
+			assert.Equal(t, dt, dt.LocalMillis().RawDateTime())
+			assert.Equal(t, dt.Time(time.UTC).UnixMicro(), int64(dt.LocalMicros()))
+			assert.Equal(t, dt.Time(time.UTC).UnixMilli(), int64(dt.LocalMillis()))
+		})
+	}
+	micros := rawdate.MustNewDateTime(2024, 2, 29, 23, 59, 59, 999_999_000)
+	assert.Equal(t, micros, micros.LocalMicros().RawDateTime())
+	assert.Equal(t, rawdate.LocalMicros(-1), rawdate.MustNewDateTime(1969, 12, 31, 23, 59, 59, 999_999_999).LocalMicros())
+	assert.Equal(t, "1970-01-01T00:00:01", rawdate.LocalMillis(1000).String())
+}
+
+func TestRawDateTime_Scan(t *testing.T) {
+	want := rawdate.MustNewDateTime(2024, 3, 20, 8, 15, 0, 0)
+	for _, src := range []any{
+		"2024-03-20 08:15:00",
+		[]byte("2024-03-20T08:15:00"),
+		"2024-03-20 08:15:00+01:00",
+		time.Date(2024, 3, 20, 8, 15, 0, 0, time.FixedZone("X", -3600)),
+	} {
+		var dt rawdate.RawDateTime
+		assert.NoError(t, dt.Scan(src))
+		assert.Equal(t, want, dt)
+	}
+	var dt rawdate.RawDateTime
+	assert.Error(t, dt.Scan(nil))
+	assert.Error(t, dt.Scan(1))
+	v, err := want.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "2024-03-20 08:15:00", v)
+}
@@ -0,0 +1,224 @@
+package rawdate
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// RawTime represents a wall clock time of day without a date or time zone, like the
+// opening hours of a shop. The zero value is midnight (00:00:00).
+//
+// For the avro `time-millis` and `time-micros` logical types use the time.Duration of
+// Duration (which hamba/avro encodes natively) and TimeFromDuration.
+type RawTime struct {
+	Hour       int8
+	Minute     int8
+	Second     int8
+	Nanosecond int32
+}
+
+// Midnight is the zero RawTime (00:00:00)
+var Midnight = RawTime{}
+
+var (
+	_ fmt.Stringer             = RawTime{}
+	_ encoding.TextMarshaler   = RawTime{}
+	_ json.Marshaler           = RawTime{}
+	_ fmt.GoStringer           = RawTime{}
+	_ driver.Valuer            = RawTime{}
+	_ encoding.TextUnmarshaler = (*RawTime)(nil)
+	_ json.Unmarshaler         = (*RawTime)(nil)
+	_ sql.Scanner              = (*RawTime)(nil)
+)
+
+// rawTimeLayouts are the layouts accepted when unmarshalling a RawTime (the time package
+// accepts fractional seconds after the seconds)
+var rawTimeLayouts = []string{time.TimeOnly, "15:04"}
+
+// NewTime creates a new RawTime given the hour, minute, second and nanosecond.
+// Returns an error if the provided values do not form a valid time of day.
+func NewTime(hour, minute, sec, nsec int) (RawTime, error) {
+	if hour < 0 || hour > 23 || minute < 0 || minute > 59 || sec < 0 || sec > 59 ||
+		nsec < 0 || nsec >= int(time.Second) {
+		return Midnight, errors.New("not a time of day")
+	}
+	return RawTime{Hour: int8(hour), Minute: int8(minute), Second: int8(sec), Nanosecond: int32(nsec)}, nil
+}
+
+// MustNewTime creates a new RawTime given the hour, minute, second and nanosecond.
+// Panics if the provided values do not form a valid time of day.
+func MustNewTime(hour, minute, sec, nsec int) RawTime {
+	t, err := NewTime(hour, minute, sec, nsec)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// TimeOf returns the wall clock time of t (in its location)
+func TimeOf(t time.Time) RawTime {
+	return RawTime{Hour: int8(t.Hour()), Minute: int8(t.Minute()), Second: int8(t.Second()), Nanosecond: int32(t.Nanosecond())}
+}
+
+// TimeFromDuration returns the time of day which is the duration after midnight.
+// The duration wraps around at 24 hours (and negative durations count back from midnight).
+func TimeFromDuration(d time.Duration) RawTime {
+	d %= 24 * time.Hour
+	if d < 0 {
+		d += 24 * time.Hour
+	}
+	return TimeOf(time.Unix(0, int64(d)).UTC())
+}
+
+// ParseTime parses a string representing a time of day with the layout.
+// Date and zone information of the layout are ignored.
+func ParseTime(layout, s string) (RawTime, error) {
+	t, err := time.Parse(layout, s)
+	if err != nil {
+		return Midnight, err
+	}
+	return TimeOf(t), nil
+}
+
+// IsValid checks whether the RawTime represents a valid time of day.
+func (t RawTime) IsValid() bool {
+	_, err := NewTime(int(t.Hour), int(t.Minute), int(t.Second), int(t.Nanosecond))
+	return err == nil
+}
+
+// IsZero checks if the RawTime is midnight.
+func (t RawTime) IsZero() bool {
+	return t == Midnight
+}
+
+// Duration returns the time since midnight
+func (t RawTime) Duration() time.Duration {
+	return time.Duration(t.Hour)*time.Hour + time.Duration(t.Minute)*time.Minute +
+		time.Duration(t.Second)*time.Second + time.Duration(t.Nanosecond)
+}
+
+// Add returns the time of day d after t (wrapping around at midnight).
+func (t RawTime) Add(d time.Duration) RawTime {
+	return TimeFromDuration(t.Duration() + d%(24*time.Hour))
+}
+
+// Compare compares the RawTime with another RawTime.
+// Returns 1 if t > u, -1 if t < u, and 0 if t == u.
+func (t RawTime) Compare(u RawTime) int {
+	switch dt, du := t.Duration(), u.Duration(); {
+	case dt > du:
+		return 1
+	case dt < du:
+		return -1
+	default:
+		return 0
+	}
+}
+
+// Equal reports whether the RawTime t is equal RawTime u.
+func (t RawTime) Equal(u RawTime) bool {
+	return t.Compare(u) == 0
+}
+
+// After reports whether the RawTime t is after RawTime u.
+func (t RawTime) After(u RawTime) bool {
+	return t.Compare(u) > 0
+}
+
+// Before reports whether the RawTime t is before RawTime u.
+func (t RawTime) Before(u RawTime) bool {
+	return t.Compare(u) < 0
+}
+
+// On returns the time.Time of the time of day at the date in the location. Like time.Date
+// it normalizes wall clock times which do not exist in the location (like in DST gaps).
+func (t RawTime) On(d RawDate, location *time.Location) time.Time {
+	return time.Date(d.Year(), d.Month(), d.Day(), int(t.Hour), int(t.Minute), int(t.Second), int(t.Nanosecond), location)
+}
+
+// At returns the RawDateTime of the date at the time of day
+func (r RawDate) At(t RawTime) RawDateTime {
+	return RawDateTime{Date: r, Clock: t}
+}
+
+// Format formats the RawTime according to the provided layout string.
+func (t RawTime) Format(format string) string {
+	return t.On(MustNew(1970, time.January, 1), time.UTC).Format(format)
+}
+
+// String returns a string representation of the RawTime in HH:MM:SS format (with
+// fractional seconds if there are any).
+func (t RawTime) String() string {
+	return t.Format("15:04:05.999999999")
+}
+
+// GoString implements `fmt.GoStringer`.
+func (t RawTime) GoString() string {
+	return fmt.Sprintf("rawdate.MustNewTime(%d, %d, %d, %d)", t.Hour, t.Minute, t.Second, t.Nanosecond)
+}
+
+// MarshalText implements the encoding.TextMarshaler
+func (t RawTime) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// MarshalJSON implements `json.Marshaler` using HH:MM:SS
+func (t RawTime) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.String())
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler for HH:MM[:SS[.fraction]]
+func (t *RawTime) UnmarshalText(data []byte) error {
+	parsed, err := parseRawTime(string(data))
+	if err != nil {
+		return err
+	}
+	*t = parsed
+	return nil
+}
+
+// UnmarshalJSON implements `json.Unmarshaler` for HH:MM[:SS[.fraction]]
+func (t *RawTime) UnmarshalJSON(data []byte) error {
+	s := ""
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	return t.UnmarshalText([]byte(s))
+}
+
+// Value implements `driver.Valuer`; marshals to a HH:MM:SS string
+func (t RawTime) Value() (driver.Value, error) {
+	return t.String(), nil
+}
+
+// Scan implements `sql.Scanner` by unmarshalling from `time.Time` (its wall clock) or a string.
+// NULL is an error, scan into a **RawTime for nullable columns.
+func (t *RawTime) Scan(src any) error {
+	switch st := src.(type) {
+	case string:
+		return t.UnmarshalText([]byte(st))
+	case []byte:
+		return t.UnmarshalText(st)
+	case time.Time:
+		*t = TimeOf(st)
+		return nil
+	default:
+		return fmt.Errorf("wrong type to scan as RawTime: type=%T", src)
+	}
+}
+
+func parseRawTime(s string) (RawTime, error) {
+	var err error
+	for _, layout := range rawTimeLayouts {
+		var t time.Time
+		if t, err = time.Parse(layout, s); err == nil {
+			return TimeOf(t), nil
+		}
+	}
+	return Midnight, err
+}
@@ -0,0 +1,117 @@
+package rawdate_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/metatexx/avrox/rawdate"
+)
+
+func TestNewTime(t *testing.T) {
+	tests := []struct {
+		h, m, s, ns int
+		valid       bool
+	}{
+		{0, 0, 0, 0, true},
+		{23, 59, 59, 999_999_999, true},
+		{24, 0, 0, 0, false},
+		{12, 60, 0, 0, false},
+		{12, 0, 60, 0, false},
+		{12, 0, 0, int(time.Second), false},
+		{-1, 0, 0, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%d:%d:%d.%d", tt.h, tt.m, tt.s, tt.ns), func(t *testing.T) {
+			rt, err := rawdate.NewTime(tt.h, tt.m, tt.s, tt.ns)
+			assert.Equal(t, tt.valid, err == nil)
+			assert.True(t, rt.IsValid())
+		})
+	}
+	assert.Panics(t, func() { rawdate.MustNewTime(25, 0, 0, 0) })
+}
+
+func TestRawTime_Text(t *testing.T) {
+	tests := []struct {
+		in   string
+		want rawdate.RawTime
+		out  string
+	}{
+		{"09:30:00", rawdate.MustNewTime(9, 30, 0, 0), "09:30:00"},
+		{"09:30", rawdate.MustNewTime(9, 30, 0, 0), "09:30:00"},
+		{"23:59:59.5", rawdate.MustNewTime(23, 59, 59, 500_000_000), "23:59:59.5"},
+		{"00:00:00.000001", rawdate.MustNewTime(0, 0, 0, 1000), "00:00:00.000001"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			var rt rawdate.RawTime
+			assert.NoError(t, rt.UnmarshalText([]byte(tt.in)))
+			assert.Equal(t, tt.want, rt)
+			assert.Equal(t, tt.out, rt.String())
+
+			data, err := json.Marshal(rt)
+			assert.NoError(t, err)
+			assert.Equal(t, `"`+tt.out+`"`, string(data))
+			var back rawdate.RawTime
+			assert.NoError(t, json.Unmarshal(data, &back))
+			assert.Equal(t, rt, back)
+		})
+	}
+	var rt rawdate.RawTime
+	for _, in := range []string{"", "24:00", "9.30", "09:30:00+01:00"} {
+		assert.Error(t, rt.UnmarshalText([]byte(in)), in)
+	}
+	assert.Error(t, json.Unmarshal([]byte(`930`), &rt))
+	assert.Equal(t, "rawdate.MustNewTime(9, 30, 0, 5)", fmt.Sprintf("%#v", rawdate.MustNewTime(9, 30, 0, 5)))
+	assert.Equal(t, "9:30AM", rawdate.MustNewTime(9, 30, 0, 5).Format(time.Kitchen))
+}
+
+func TestRawTime_Duration(t *testing.T) {
+	rt := rawdate.MustNewTime(22, 30, 15, 42)
+	assert.Equal(t, 22*time.Hour+30*time.Minute+15*time.Second+42, rt.Duration())
+	assert.Equal(t, rt, rawdate.TimeFromDuration(rt.Duration()))
+	assert.Equal(t, rawdate.MustNewTime(1, 0, 15, 42), rt.Add(150*time.Minute))
+	assert.Equal(t, rawdate.MustNewTime(23, 0, 0, 0), rawdate.Midnight.Add(-time.Hour))
+	assert.Equal(t, rawdate.MustNewTime(2, 0, 0, 0), rawdate.TimeFromDuration(50*time.Hour))
+	assert.True(t, rt.After(rawdate.MustNewTime(22, 30, 15, 41)))
+	assert.True(t, rawdate.Midnight.Before(rt))
+	assert.True(t, rt.Equal(rt))
+	assert.True(t, rawdate.Midnight.IsZero())
+}
+
+func TestRawTime_On(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skip("no time zone database")
+	}
+	rt := rawdate.MustNewTime(9, 0, 0, 0)
+	tm := rt.On(ymd(2024, 7, 1), berlin)
+	assert.Equal(t, "2024-07-01T09:00:00+02:00", tm.Format(time.RFC3339))
+	assert.Equal(t, rt, rawdate.TimeOf(tm))
+	assert.Equal(t, "2024-01-01T09:00:00+01:00", rt.On(ymd(2024, 1, 1), berlin).Format(time.RFC3339))
+}
+
+func TestRawTime_Scan(t *testing.T) {
+	tests := []struct {
+		src  any
+		want rawdate.RawTime
+	}{
+		{"08:15:00", rawdate.MustNewTime(8, 15, 0, 0)},
+		{[]byte("08:15"), rawdate.MustNewTime(8, 15, 0, 0)},
+		{time.Date(0, 1, 1, 8, 15, 0, 0, time.FixedZone("X", 3600)), rawdate.MustNewTime(8, 15, 0, 0)},
+	}
+	for _, tt := range tests {
+		var rt rawdate.RawTime
+		assert.NoError(t, rt.Scan(tt.src))
+		assert.Equal(t, tt.want, rt)
+	}
+	var rt rawdate.RawTime
+	assert.Error(t, rt.Scan(815))
+	assert.Error(t, rt.Scan(nil))
+	v, err := rawdate.MustNewTime(8, 15, 0, 0).Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "08:15:00", v)
+}
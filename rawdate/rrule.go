@@ -0,0 +1,476 @@
+package rawdate
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Frequency is the FREQ of a recurrence rule
+type Frequency string
+
+const (
+	Daily   Frequency = "DAILY"
+	Weekly  Frequency = "WEEKLY"
+	Monthly Frequency = "MONTHLY"
+	Yearly  Frequency = "YEARLY"
+)
+
+var ErrRecurrence = errors.New("recurrence rule is invalid")
+
+// maxRecurrenceYear stops the expansion of rules which do not match (anymore)
+const maxRecurrenceYear = 9999
+
+// MaxRecurrenceInterval is the largest INTERVAL which Validate accepts
+const MaxRecurrenceInterval = 1000
+
+// WeekdayNum is an entry of BYDAY like "MO", "2TU" or "-1FR". N selects the n-th weekday
+// of the month (MONTHLY) or year (YEARLY) and counts from the end when negative.
+// N is 0 for every weekday of the period.
+type WeekdayNum struct {
+	N       int
+	Weekday time.Weekday
+}
+
+var weekdayCodes = [7]string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+// String returns the BYDAY entry
+func (w WeekdayNum) String() string {
+	if w.N == 0 {
+		return weekdayCodes[w.Weekday]
+	}
+	return strconv.Itoa(w.N) + weekdayCodes[w.Weekday]
+}
+
+// Recurrence is a date-only subset of the RFC 5545 RRULE (FREQ, INTERVAL, BYDAY, BYMONTHDAY,
+// BYSETPOS, COUNT and UNTIL) together with its start date (DTSTART). Weeks start on Monday.
+//
+// The occurrences are the dates of the rule from Start on. Unlike RFC 5545, Start itself is
+// only an occurrence when it matches the rule. Dates which do not exist (like the 31st in
+// April) are skipped.
+//
+// Rules without COUNT and UNTIL which never match (anymore) get expanded period by period up
+// to the year 9999 by All, Between and After. For DAILY rules from untrusted sources that
+// are about 3 million periods, so use a COUNT or UNTIL or stop the iteration early.
+//
+// Examples:
+//
+//	FREQ=MONTHLY;BYMONTHDAY=1                   invoice on the first of every month
+//	FREQ=WEEKLY;INTERVAL=2;BYDAY=TU             every second Tuesday
+//	FREQ=MONTHLY;INTERVAL=3;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1
+//	                                            last weekday of the quarter (Start in March)
+type Recurrence struct {
+	Start      RawDate
+	Freq       Frequency
+	Interval   int // 0 is the same as 1 (up to MaxRecurrenceInterval)
+	ByDay      []WeekdayNum
+	ByMonthDay []int   // 1 to 31 or -31 to -1 (from the end of the month)
+	BySetPos   []int   // 1 to 366 or -366 to -1 (from the end of the occurrences of a period)
+	Count      int     // 0 for no limit
+	Until      RawDate // Zero for no limit
+}
+
+// ParseRecurrence parses the rule (with or without "RRULE:" prefix) for the start date
+func ParseRecurrence(start RawDate, rule string) (Recurrence, error) {
+	r := Recurrence{Start: start}
+	rule = strings.TrimPrefix(strings.TrimSpace(rule), "RRULE:")
+	seen := map[string]bool{}
+	for _, part := range strings.Split(rule, ";") {
+		name, value, found := strings.Cut(part, "=")
+		name = strings.ToUpper(name)
+		if !found || value == "" || seen[name] {
+			return Recurrence{}, fmt.Errorf("%w: %q", ErrRecurrence, part)
+		}
+		seen[name] = true
+		var err error
+		switch name {
+		case "FREQ":
+			r.Freq = Frequency(strings.ToUpper(value))
+		case "INTERVAL":
+			r.Interval, err = strconv.Atoi(value)
+		case "COUNT":
+			r.Count, err = strconv.Atoi(value)
+		case "UNTIL":
+			// a date-time gets cut to its date
+			if len(value) > 8 && value[8] == 'T' {
+				value = value[:8]
+			}
+			r.Until, err = Parse("20060102", value)
+		case "BYDAY":
+			for _, v := range strings.Split(value, ",") {
+				var wn WeekdayNum
+				if wn, err = parseWeekdayNum(v); err != nil {
+					break
+				}
+				r.ByDay = append(r.ByDay, wn)
+			}
+		case "BYMONTHDAY":
+			r.ByMonthDay, err = parseInts(value)
+		case "BYSETPOS":
+			r.BySetPos, err = parseInts(value)
+		default:
+			return Recurrence{}, fmt.Errorf("%w: %s is not supported", ErrRecurrence, name)
+		}
+		if err != nil {
+			return Recurrence{}, fmt.Errorf("%w: %q", ErrRecurrence, part)
+		}
+	}
+	if err := r.Validate(); err != nil {
+		return Recurrence{}, err
+	}
+	return r, nil
+}
+
+// MustParseRecurrence is like ParseRecurrence but panics if the rule is invalid
+func MustParseRecurrence(start RawDate, rule string) Recurrence {
+	r, err := ParseRecurrence(start, rule)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+func parseWeekdayNum(s string) (WeekdayNum, error) {
+	s = strings.ToUpper(strings.TrimSpace(s))
+	if len(s) < 2 {
+		return WeekdayNum{}, ErrRecurrence
+	}
+	var wn WeekdayNum
+	found := false
+	for i, code := range weekdayCodes {
+		if strings.HasSuffix(s, code) {
+			wn.Weekday, found = time.Weekday(i), true
+		}
+	}
+	if !found {
+		return WeekdayNum{}, ErrRecurrence
+	}
+	if n := s[:len(s)-2]; n != "" {
+		var err error
+		if wn.N, err = strconv.Atoi(n); err != nil || wn.N == 0 {
+			return WeekdayNum{}, ErrRecurrence
+		}
+	}
+	return wn, nil
+}
+
+func parseInts(s string) ([]int, error) {
+	var result []int
+	for _, v := range strings.Split(s, ",") {
+		i, err := strconv.Atoi(strings.TrimSpace(v))
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, i)
+	}
+	return result, nil
+}
+
+// Validate checks the rule
+func (r Recurrence) Validate() error {
+	invalid := func(reason string) error {
+		return fmt.Errorf("%w: %s", ErrRecurrence, reason)
+	}
+	switch r.Freq {
+	case Daily, Weekly, Monthly, Yearly:
+	default:
+		return invalid("FREQ must be DAILY, WEEKLY, MONTHLY or YEARLY")
+	}
+	if r.Interval < 0 || r.Count < 0 {
+		return invalid("INTERVAL and COUNT must be positive")
+	}
+	if r.Interval > MaxRecurrenceInterval {
+		return invalid("INTERVAL must not exceed " + strconv.Itoa(MaxRecurrenceInterval))
+	}
+	if r.Count > 0 && !r.Until.IsZero() {
+		return invalid("COUNT and UNTIL must not be used together")
+	}
+	for _, wn := range r.ByDay {
+		if wn.N != 0 && (r.Freq == Daily || r.Freq == Weekly) {
+			return invalid("BYDAY with a number needs FREQ MONTHLY or YEARLY")
+		}
+		if wn.N < -53 || wn.N > 53 || wn.Weekday < time.Sunday || wn.Weekday > time.Saturday {
+			return invalid("BYDAY is out of range")
+		}
+	}
+	if len(r.ByMonthDay) > 0 && r.Freq == Weekly {
+		return invalid("BYMONTHDAY must not be used with FREQ WEEKLY")
+	}
+	for _, md := range r.ByMonthDay {
+		if md == 0 || md < -31 || md > 31 {
+			return invalid("BYMONTHDAY is out of range")
+		}
+	}
+	if len(r.BySetPos) > 0 && len(r.ByDay) == 0 && len(r.ByMonthDay) == 0 {
+		return invalid("BYSETPOS needs BYDAY or BYMONTHDAY")
+	}
+	for _, pos := range r.BySetPos {
+		if pos == 0 || pos < -366 || pos > 366 {
+			return invalid("BYSETPOS is out of range")
+		}
+	}
+	return nil
+}
+
+// String returns the rule (without "RRULE:" prefix and without the start date). INTERVAL
+// is only omitted for 0, so ParseRecurrence returns an equal Recurrence.
+func (r Recurrence) String() string {
+	parts := []string{"FREQ=" + string(r.Freq)}
+	if r.Interval > 0 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if len(r.ByDay) > 0 {
+		days := make([]string, len(r.ByDay))
+		for i, wn := range r.ByDay {
+			days[i] = wn.String()
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+	if len(r.ByMonthDay) > 0 {
+		parts = append(parts, "BYMONTHDAY="+joinInts(r.ByMonthDay))
+	}
+	if len(r.BySetPos) > 0 {
+		parts = append(parts, "BYSETPOS="+joinInts(r.BySetPos))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	if !r.Until.IsZero() {
+		parts = append(parts, "UNTIL="+r.Until.Format("20060102"))
+	}
+	return strings.Join(parts, ";")
+}
+
+func joinInts(ints []int) string {
+	s := make([]string, len(ints))
+	for i, v := range ints {
+		s[i] = strconv.Itoa(v)
+	}
+	return strings.Join(s, ",")
+}
+
+// All returns an iterator over the occurrences. It matches iter.Seq[RawDate], so it can
+// be used with range-over-func or called with a yield function directly. Without COUNT
+// and UNTIL it only ends at the year 9999 (or when yield returns false).
+func (r Recurrence) All() func(yield func(RawDate) bool) {
+	return func(yield func(RawDate) bool) {
+		if r.Validate() != nil {
+			return
+		}
+		interval := max(r.Interval, 1)
+		start := int(r.Start.Days())
+		count := 0
+		for period := 0; ; period += interval {
+			first, dates := r.period(period)
+			if first > daysFromCivil(maxRecurrenceYear, 12, 31) {
+				return
+			}
+			for _, days := range dates {
+				if days < start {
+					continue
+				}
+				d := FromDays(Days(days))
+				if !r.Until.IsZero() && d.After(r.Until) {
+					return
+				}
+				if !yield(d) {
+					return
+				}
+				count++
+				if r.Count > 0 && count >= r.Count {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Between returns the occurrences from (including) to to (including)
+func (r Recurrence) Between(from, to RawDate) []RawDate {
+	var dates []RawDate
+	r.All()(func(d RawDate) bool {
+		if d.After(to) {
+			return false
+		}
+		if !d.Before(from) {
+			dates = append(dates, d)
+		}
+		return true
+	})
+	return dates
+}
+
+// After returns the first occurrence after d. If orToday is true and d is an occurrence,
+// it returns d. It returns false when there is no further occurrence.
+func (r Recurrence) After(d RawDate, orToday bool) (RawDate, bool) {
+	var next RawDate
+	found := false
+	r.All()(func(o RawDate) bool {
+		if o.After(d) || (orToday && o.Equal(d)) {
+			next, found = o, true
+			return false
+		}
+		return true
+	})
+	return next, found
+}
+
+// period returns the first day and the (sorted) occurrences of the n-th period from Start on
+func (r Recurrence) period(n int) (int, []int) {
+	var first int
+	var dates []int
+	switch r.Freq {
+	case Daily:
+		first = int(r.Start.Days()) + n
+		if r.matchesDay(first) && r.matchesMonthDay(first) {
+			dates = []int{first}
+		}
+	case Weekly:
+		start := int(r.Start.Days())
+		first = start - isoWeekday(start) + 1 + n*7
+		if len(r.ByDay) == 0 {
+			dates = []int{first + isoWeekday(start) - 1}
+		}
+		for _, wn := range r.ByDay {
+			dates = append(dates, first+isoWeekdayOf(wn.Weekday)-1)
+		}
+	case Monthly:
+		month0 := r.Start.Year0*12 + int(r.Start.Month0) + n
+		year, month := month0/12+1, month0%12+1
+		first = daysFromCivil(year, month, 1)
+		dates = r.expand(first, first+DaysIn(year, time.Month(month))-1, []int{month})
+	case Yearly:
+		year := r.Start.Year() + n
+		first = daysFromCivil(year, 1, 1)
+		months := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}
+		if len(r.ByMonthDay) == 0 && len(r.ByDay) == 0 {
+			months = []int{int(r.Start.Month())}
+		}
+		dates = r.expand(first, daysFromCivil(year, 12, 31), months)
+	}
+	sort.Ints(dates)
+	dates = uniqueInts(dates)
+	if len(r.BySetPos) > 0 {
+		var selected []int
+		for _, pos := range r.BySetPos {
+			if pos < 0 {
+				pos += len(dates) + 1
+			}
+			if pos >= 1 && pos <= len(dates) {
+				selected = append(selected, dates[pos-1])
+			}
+		}
+		sort.Ints(selected)
+		dates = uniqueInts(selected)
+	}
+	return first, dates
+}
+
+// expand returns the dates of a month or year (from first to last) for the months
+func (r Recurrence) expand(first, last int, months []int) []int {
+	year, _, _ := civilFromDays(first)
+	var dates []int
+	switch {
+	case len(r.ByMonthDay) > 0:
+		for _, month := range months {
+			daysIn := DaysIn(year, time.Month(month))
+			for _, md := range r.ByMonthDay {
+				if md < 0 {
+					md += daysIn + 1
+				}
+				if md < 1 || md > daysIn {
+					continue
+				}
+				days := daysFromCivil(year, month, md)
+				if len(r.ByDay) == 0 || r.matchesNthDay(days, first, last) {
+					dates = append(dates, days)
+				}
+			}
+		}
+	case len(r.ByDay) > 0:
+		for _, wn := range r.ByDay {
+			dates = append(dates, nthWeekdays(first, last, wn)...)
+		}
+	default:
+		for _, month := range months {
+			if day := r.Start.Day(); day <= DaysIn(year, time.Month(month)) {
+				dates = append(dates, daysFromCivil(year, month, day))
+			}
+		}
+	}
+	return dates
+}
+
+// nthWeekdays returns the days of the weekday from first to last (all or the n-th one)
+func nthWeekdays(first, last int, wn WeekdayNum) []int {
+	wd := isoWeekdayOf(wn.Weekday)
+	switch {
+	case wn.N > 0:
+		days := first + (wd-isoWeekday(first)+7)%7 + (wn.N-1)*7
+		if days <= last {
+			return []int{days}
+		}
+	case wn.N < 0:
+		days := last - (isoWeekday(last)-wd+7)%7 + (wn.N+1)*7
+		if days >= first {
+			return []int{days}
+		}
+	default:
+		var dates []int
+		for days := first + (wd-isoWeekday(first)+7)%7; days <= last; days += 7 {
+			dates = append(dates, days)
+		}
+		return dates
+	}
+	return nil
+}
+
+// matchesNthDay reports whether days is one of the BYDAY days of the period
+func (r Recurrence) matchesNthDay(days, first, last int) bool {
+	for _, wn := range r.ByDay {
+		for _, d := range nthWeekdays(first, last, wn) {
+			if d == days {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (r Recurrence) matchesDay(days int) bool {
+	if len(r.ByDay) == 0 {
+		return true
+	}
+	for _, wn := range r.ByDay {
+		if isoWeekdayOf(wn.Weekday) == isoWeekday(days) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r Recurrence) matchesMonthDay(days int) bool {
+	if len(r.ByMonthDay) == 0 {
+		return true
+	}
+	year, month, day := civilFromDays(days)
+	for _, md := range r.ByMonthDay {
+		if md == day || md+DaysIn(year, time.Month(month))+1 == day {
+			return true
+		}
+	}
+	return false
+}
+
+func uniqueInts(sorted []int) []int {
+	result := sorted[:0]
+	for i, v := range sorted {
+		if i == 0 || v != sorted[i-1] {
+			result = append(result, v)
+		}
+	}
+	return result
+}
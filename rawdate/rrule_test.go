@@ -0,0 +1,119 @@
+package rawdate_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/metatexx/avrox/rawdate"
+)
+
+func collect(r rawdate.Recurrence) []rawdate.RawDate {
+	var dates []rawdate.RawDate
+	r.All()(func(d rawdate.RawDate) bool {
+		dates = append(dates, d)
+		return len(dates) < 100
+	})
+	return dates
+}
+
+func TestRecurrence(t *testing.T) {
+	tests := []struct {
+		name  string
+		start rawdate.RawDate
+		rule  string
+		want  []rawdate.RawDate
+	}{
+		{"monthly invoice", ymd(2024, 1, 15), "FREQ=MONTHLY;BYMONTHDAY=1;COUNT=3",
+			[]rawdate.RawDate{ymd(2024, 2, 1), ymd(2024, 3, 1), ymd(2024, 4, 1)}},
+		{"every second tuesday", ymd(2024, 1, 2), "FREQ=WEEKLY;INTERVAL=2;BYDAY=TU;COUNT=3",
+			[]rawdate.RawDate{ymd(2024, 1, 2), ymd(2024, 1, 16), ymd(2024, 1, 30)}},
+		{"last weekday of quarter", ymd(2024, 3, 1), "FREQ=MONTHLY;INTERVAL=3;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1;COUNT=4",
+			[]rawdate.RawDate{ymd(2024, 3, 29), ymd(2024, 6, 28), ymd(2024, 9, 30), ymd(2024, 12, 31)}},
+		{"last day of month", ymd(2024, 1, 31), "FREQ=MONTHLY;BYMONTHDAY=-1;COUNT=3",
+			[]rawdate.RawDate{ymd(2024, 1, 31), ymd(2024, 2, 29), ymd(2024, 3, 31)}},
+		{"skips missing days", ymd(2024, 1, 31), "RRULE:FREQ=MONTHLY;COUNT=3",
+			[]rawdate.RawDate{ymd(2024, 1, 31), ymd(2024, 3, 31), ymd(2024, 5, 31)}},
+		{"second tuesday until", ymd(2024, 1, 1), "FREQ=MONTHLY;BYDAY=2TU;UNTIL=20240401",
+			[]rawdate.RawDate{ymd(2024, 1, 9), ymd(2024, 2, 13), ymd(2024, 3, 12)}},
+		{"until date-time", ymd(2024, 1, 1), "FREQ=MONTHLY;BYDAY=2TU;UNTIL=20240312T000000Z",
+			[]rawdate.RawDate{ymd(2024, 1, 9), ymd(2024, 2, 13), ymd(2024, 3, 12)}},
+		{"every ten days", ymd(2024, 2, 25), "FREQ=DAILY;INTERVAL=10;COUNT=3",
+			[]rawdate.RawDate{ymd(2024, 2, 25), ymd(2024, 3, 6), ymd(2024, 3, 16)}},
+		{"leap day", ymd(2024, 2, 29), "FREQ=YEARLY;COUNT=2",
+			[]rawdate.RawDate{ymd(2024, 2, 29), ymd(2028, 2, 29)}},
+		{"last friday of year", ymd(2024, 1, 1), "FREQ=YEARLY;BYDAY=-1FR;COUNT=2",
+			[]rawdate.RawDate{ymd(2024, 12, 27), ymd(2025, 12, 26)}},
+		{"yearly month day", ymd(2024, 10, 1), "FREQ=YEARLY;BYMONTHDAY=-1;COUNT=3",
+			[]rawdate.RawDate{ymd(2024, 10, 31), ymd(2024, 11, 30), ymd(2024, 12, 31)}},
+		{"weekly days", ymd(2024, 1, 3), "FREQ=WEEKLY;BYDAY=MO,WE,FR;COUNT=4",
+			[]rawdate.RawDate{ymd(2024, 1, 3), ymd(2024, 1, 5), ymd(2024, 1, 8), ymd(2024, 1, 10)}},
+		{"friday 13th", ymd(2024, 1, 1), "FREQ=MONTHLY;BYDAY=FR;BYMONTHDAY=13;COUNT=3",
+			[]rawdate.RawDate{ymd(2024, 9, 13), ymd(2024, 12, 13), ymd(2025, 6, 13)}},
+		{"weekends", ymd(2024, 1, 1), "FREQ=DAILY;BYDAY=SA,SU;COUNT=3",
+			[]rawdate.RawDate{ymd(2024, 1, 6), ymd(2024, 1, 7), ymd(2024, 1, 13)}},
+		{"first and last", ymd(2024, 1, 1), "FREQ=MONTHLY;BYMONTHDAY=1,-1;BYSETPOS=1,-1;COUNT=4",
+			[]rawdate.RawDate{ymd(2024, 1, 1), ymd(2024, 1, 31), ymd(2024, 2, 1), ymd(2024, 2, 29)}},
+		{"never matches", ymd(2024, 2, 1), "FREQ=MONTHLY;INTERVAL=12;BYMONTHDAY=30", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := rawdate.ParseRecurrence(tt.start, tt.rule)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, collect(r))
+
+			again, err := rawdate.ParseRecurrence(tt.start, r.String())
+			assert.NoError(t, err)
+			assert.Equal(t, r, again)
+		})
+	}
+}
+
+func TestRecurrence_Invalid(t *testing.T) {
+	for _, rule := range []string{
+		"",
+		"INTERVAL=2",
+		"FREQ=HOURLY",
+		"FREQ=DAILY;FREQ=DAILY",
+		"FREQ=WEEKLY;BYDAY=1MO",
+		"FREQ=WEEKLY;BYMONTHDAY=1",
+		"FREQ=MONTHLY;COUNT=2;UNTIL=20240101",
+		"FREQ=MONTHLY;BYHOUR=1",
+		"FREQ=MONTHLY;BYSETPOS=1",
+		"FREQ=MONTHLY;BYMONTHDAY=0",
+		"FREQ=MONTHLY;BYDAY=0MO",
+		"FREQ=MONTHLY;BYDAY=XX",
+		"FREQ=MONTHLY;UNTIL=2024",
+		"FREQ=MONTHLY;INTERVAL=-1",
+		"FREQ=DAILY;INTERVAL=1001",
+	} {
+		_, err := rawdate.ParseRecurrence(ymd(2024, 1, 1), rule)
+		assert.ErrorIs(t, err, rawdate.ErrRecurrence, rule)
+	}
+}
+
+func TestRecurrence_String(t *testing.T) {
+	for _, interval := range []int{0, 1, 2} {
+		r := rawdate.Recurrence{Start: ymd(2024, 1, 1), Freq: rawdate.Weekly, Interval: interval}
+		again, err := rawdate.ParseRecurrence(r.Start, r.String())
+		assert.NoError(t, err)
+		assert.Equal(t, r, again)
+	}
+}
+
+func TestRecurrence_After(t *testing.T) {
+	r := rawdate.MustParseRecurrence(ymd(2024, 1, 1), "FREQ=MONTHLY;BYDAY=2TU;UNTIL=20240401")
+	next, ok := r.After(ymd(2024, 2, 13), false)
+	assert.True(t, ok)
+	assert.Equal(t, ymd(2024, 3, 12), next)
+	next, ok = r.After(ymd(2024, 2, 13), true)
+	assert.True(t, ok)
+	assert.Equal(t, ymd(2024, 2, 13), next)
+	_, ok = r.After(ymd(2024, 3, 12), false)
+	assert.False(t, ok)
+
+	assert.Equal(t, []rawdate.RawDate{ymd(2024, 2, 13), ymd(2024, 3, 12)}, r.Between(ymd(2024, 2, 1), ymd(2024, 12, 31)))
+
+	forever := rawdate.MustParseRecurrence(ymd(2024, 1, 1), "FREQ=WEEKLY")
+	assert.Len(t, forever.Between(ymd(2024, 1, 1), ymd(2024, 12, 31)), 53)
+}
@@ -2,7 +2,9 @@ package rawdate_test
 
 import (
 	"database/sql"
+	"errors"
 	"testing"
+	"time"
 
 	_ "github.com/mattn/go-sqlite3"
 
@@ -123,3 +125,207 @@ func TestRawDateInSQLite3TEXTTime(t *testing.T) {
 		t.Errorf("Retrieved date %v does not match inserted date %v", retrievedDate, testDate)
 	}
 }
+
+// TestDialectsInSQLite3 stores dates with the different dialects in parallel (they do not
+// share any global state) and checks the stored representation and the scanned values
+func TestDialectsInSQLite3(t *testing.T) {
+	sqliteTime := rawdate.SQLite
+	sqliteTime.Name = "sqlite-time"
+	sqliteTime.ValueTime = true
+	sqliteTime.TimeZone = time.FixedZone("CET", 3600)
+
+	testDate := rawdate.MustNew(2024, time.March, 20)
+	tests := []struct {
+		dialect    rawdate.Dialect
+		columnType string
+		stored     string
+		storedZero string
+	}{
+		{rawdate.ISO, "TEXT", "2024-03-20", "0001-01-01"},
+		{rawdate.ISO, "DATE", "2024-03-20", "0001-01-01"},
+		{rawdate.SQLite, "TEXT", "2024-03-20", "0000-01-01"},
+		{rawdate.SQLite, "DATE", "2024-03-20", "0000-01-01"},
+		{sqliteTime, "TEXT", "2024-03-20 00:00:00+01:00", "0001-01-01 00:00:00+01:00"},
+		{sqliteTime, "DATE", "2024-03-20 00:00:00+01:00", "0001-01-01 00:00:00+01:00"},
+		{rawdate.MSSQLLegacy, "TEXT", "20240320", "19000101"},
+		{rawdate.MSSQLLegacy, "CHAR(8)", "20240320", "19000101"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.dialect.Name+"-"+tt.columnType, func(t *testing.T) {
+			t.Parallel()
+			dl := tt.dialect
+			db, err := sql.Open("sqlite3", ":memory:")
+			if err != nil {
+				t.Fatalf("Error opening database: %v", err)
+			}
+			defer db.Close()
+
+			_, err = db.Exec("CREATE TABLE test_table (ID INTEGER, Date " + tt.columnType + ", NullDate " + tt.columnType + ")")
+			if err != nil {
+				t.Fatalf("Error creating table: %v", err)
+			}
+			_, err = db.Exec("INSERT INTO test_table (ID, Date, NullDate) VALUES (1, ?, ?), (2, ?, ?)",
+				dl.Valuer(testDate), dl.NullValuer(rawdate.NullRawDate{}),
+				dl.Valuer(rawdate.Zero), dl.NullValuer(rawdate.NullRawDate{RawDate: testDate, Valid: true}))
+			if err != nil {
+				t.Fatalf("Error inserting data: %v", err)
+			}
+
+			// the stored representation
+			var stored, storedZero string
+			err = db.QueryRow("SELECT (SELECT CAST(Date AS TEXT) FROM test_table WHERE ID = 1), (SELECT CAST(Date AS TEXT) FROM test_table WHERE ID = 2)").Scan(&stored, &storedZero)
+			if err != nil {
+				t.Fatalf("Error retrieving data: %v", err)
+			}
+			if stored != tt.stored || storedZero != tt.storedZero {
+				t.Errorf("Stored %q and %q, want %q and %q", stored, storedZero, tt.stored, tt.storedZero)
+			}
+
+			// the scanned values
+			rows, err := db.Query("SELECT Date, NullDate FROM test_table ORDER BY ID")
+			if err != nil {
+				t.Fatalf("Error retrieving data: %v", err)
+			}
+			defer rows.Close()
+			var dates []rawdate.RawDate
+			var nullDates []rawdate.NullRawDate
+			for rows.Next() {
+				var date rawdate.RawDate
+				var nullDate rawdate.NullRawDate
+				if err = rows.Scan(dl.Scanner(&date), dl.NullScanner(&nullDate)); err != nil {
+					t.Fatalf("Error scanning data: %v", err)
+				}
+				dates = append(dates, date)
+				nullDates = append(nullDates, nullDate)
+			}
+			if len(dates) != 2 || dates[0] != testDate || dates[1] != rawdate.Zero {
+				t.Errorf("Retrieved dates %v do not match inserted dates", dates)
+			}
+			if len(nullDates) != 2 || nullDates[0].Valid || nullDates[1] != (rawdate.NullRawDate{RawDate: testDate, Valid: true}) {
+				t.Errorf("Retrieved null dates %v do not match inserted dates", nullDates)
+			}
+		})
+	}
+}
+
+// TestNullRawDateInSQLite3 tests the NullRawDate with the global settings
+func TestNullRawDateInSQLite3(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("Error opening database: %v", err)
+	}
+	defer db.Close()
+
+	_, err = db.Exec("CREATE TABLE test_table (ID INTEGER, Date DATE)")
+	if err != nil {
+		t.Fatalf("Error creating table: %v", err)
+	}
+	testDate := rawdate.NullRawDate{RawDate: rawdate.Today(), Valid: true}
+	_, err = db.Exec("INSERT INTO test_table (ID, Date) VALUES (1, ?), (2, ?)", testDate, rawdate.NullRawDate{})
+	if err != nil {
+		t.Fatalf("Error inserting data: %v", err)
+	}
+
+	var retrieved, retrievedNull rawdate.NullRawDate
+	err = db.QueryRow("SELECT Date FROM test_table WHERE ID = 1").Scan(&retrieved)
+	if err != nil {
+		t.Fatalf("Error retrieving data: %v", err)
+	}
+	err = db.QueryRow("SELECT Date FROM test_table WHERE ID = 2").Scan(&retrievedNull)
+	if err != nil {
+		t.Fatalf("Error retrieving data: %v", err)
+	}
+	if retrieved != testDate || retrievedNull.Valid {
+		t.Errorf("Retrieved dates %v and %v do not match inserted dates", retrieved, retrievedNull)
+	}
+}
+
+func TestNullConventionInSQLite3(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("Error opening database: %v", err)
+	}
+	defer db.Close()
+
+	_, err = db.Exec("CREATE TABLE test_table (ID INTEGER, Date TEXT)")
+	if err != nil {
+		t.Fatalf("Error creating table: %v", err)
+	}
+	dl := rawdate.MSSQLLegacy
+	dl.Null = rawdate.ZeroIsNull
+	_, err = db.Exec("INSERT INTO test_table (ID, Date) VALUES (1, ?), (2, ?), (3, '19000101')",
+		dl.Valuer(rawdate.Zero), dl.Valuer(rawdate.MustNew(2024, 2, 29)))
+	if err != nil {
+		t.Fatalf("Error inserting data: %v", err)
+	}
+
+	var isNull bool
+	if err = db.QueryRow("SELECT Date IS NULL FROM test_table WHERE ID = 1").Scan(&isNull); err != nil || !isNull {
+		t.Fatalf("Zero date was not stored as NULL: %v", err)
+	}
+	for id, want := range map[int]rawdate.NullRawDate{
+		1: {},
+		2: rawdate.NewNullRawDate(rawdate.MustNew(2024, 2, 29)),
+		3: {},
+	} {
+		var n rawdate.NullRawDate
+		var d rawdate.RawDate
+		err = db.QueryRow("SELECT Date, Date FROM test_table WHERE ID = ?", id).Scan(dl.NullScanner(&n), dl.Scanner(&d))
+		if err != nil {
+			t.Fatalf("Error retrieving data: %v", err)
+		}
+		if n != want || d != rawdate.ZeroIsNull.RawDate(want) {
+			t.Errorf("Retrieved dates %v and %v do not match %v", n, d, want)
+		}
+	}
+
+	// plain RawDate does not scan NULL (its Value would not store NULL again)
+	var d rawdate.RawDate
+	if err = db.QueryRow("SELECT NULL").Scan(&d); !errors.Is(err, rawdate.ErrNullDate) {
+		t.Errorf("NULL did not fail to scan as RawDate: %v %v", d, err)
+	}
+}
+
+func TestRawDateTimeInSQLite3(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("Error opening database: %v", err)
+	}
+	defer db.Close()
+
+	_, err = db.Exec("CREATE TABLE test_table (ID INTEGER, At DATETIME, Opens TEXT)")
+	if err != nil {
+		t.Fatalf("Error creating table: %v", err)
+	}
+	at := rawdate.MustNewDateTime(2024, 3, 31, 2, 30, 0, 500_000_000)
+	opens := rawdate.MustNewTime(9, 30, 0, 0)
+	_, err = db.Exec("INSERT INTO test_table (ID, At, Opens) VALUES (1, ?, ?)", at, opens)
+	if err != nil {
+		t.Fatalf("Error inserting data: %v", err)
+	}
+
+	var retrievedAt rawdate.RawDateTime
+	var retrievedOpens rawdate.RawTime
+	var hour string
+	err = db.QueryRow("SELECT At, Opens, strftime('%H', At) FROM test_table WHERE ID = 1").Scan(&retrievedAt, &retrievedOpens, &hour)
+	if err != nil {
+		t.Fatalf("Error retrieving data: %v", err)
+	}
+	if retrievedAt != at || retrievedOpens != opens || hour != "02" {
+		t.Errorf("Retrieved %v, %v and %s do not match inserted %v and %v", retrievedAt, retrievedOpens, hour, at, opens)
+	}
+
+	// NULL is no time, nullable columns scan into pointers
+	if err = db.QueryRow("SELECT NULL").Scan(&retrievedOpens); err == nil {
+		t.Errorf("NULL did not fail to scan as RawTime")
+	}
+	var nullAt *rawdate.RawDateTime
+	var nullOpens *rawdate.RawTime
+	if err = db.QueryRow("SELECT NULL, Opens FROM test_table WHERE ID = 1").Scan(&nullAt, &nullOpens); err != nil {
+		t.Fatalf("Error retrieving data: %v", err)
+	}
+	if nullAt != nil || nullOpens == nil || *nullOpens != opens {
+		t.Errorf("Retrieved %v and %v do not match NULL and %v", nullAt, nullOpens, opens)
+	}
+}
@@ -0,0 +1,104 @@
+package avrox
+
+import (
+	"errors"
+	"reflect"
+	"sync"
+
+	"github.com/hamba/avro/v2"
+)
+
+var ErrSchemerRegistered = sentinel("ErrSchemerRegistered", "schemer is already registered")
+
+type registryEntry struct {
+	typ    reflect.Type
+	schema avro.Schema
+}
+
+// Registry maps the namespace and schema id of the magic to schemer types, so data can be
+// unmarshalled without knowing its type up front. The basic types are always known.
+// The zero value is an empty registry. It is safe for concurrent use.
+type Registry struct {
+	mu      sync.RWMutex
+	entries map[NamespaceID]map[SchemaID]registryEntry
+}
+
+// NewRegistry creates a registry with the schemers
+func NewRegistry(schemers ...Schemer) (*Registry, error) {
+	r := &Registry{}
+	if err := r.Register(schemers...); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Register adds the types of the schemers (nil pointers of the types are fine). It
+// returns ErrSchemerRegistered when the namespace and schema id is already used and
+// ErrNoPointerDestination for schemers which are nil interfaces or no pointers.
+func (r *Registry) Register(schemers ...Schemer) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.entries == nil {
+		r.entries = map[NamespaceID]map[SchemaID]registryEntry{}
+	}
+	for _, schemer := range schemers {
+		t := reflect.TypeOf(schemer)
+		if t == nil || t.Kind() != reflect.Ptr {
+			return ErrNoPointerDestination
+		}
+		value := reflect.New(t.Elem()).Interface().(Schemer)
+		nID, sID := value.NamespaceID(), value.SchemaID()
+		if _, found := r.entries[nID][sID]; found || (nID == NamespaceBasic && basicSchema(sID) != nil) {
+			return errors.Join(ErrSchemerRegistered, errors.New(NSV(nID, sID)))
+		}
+		schema, err := avro.Parse(value.Schema())
+		if err != nil {
+			return errors.Join(ErrSchemaInvalid, err)
+		}
+		if r.entries[nID] == nil {
+			r.entries[nID] = map[SchemaID]registryEntry{}
+		}
+		r.entries[nID][sID] = registryEntry{typ: t.Elem(), schema: schema}
+	}
+	return nil
+}
+
+// New returns a new allocated schemer and its schema for the ids.
+// A nil registry only knows the basic types.
+func (r *Registry) New(nID NamespaceID, sID SchemaID) (Schemer, avro.Schema, bool) {
+	if nID == NamespaceBasic {
+		if bt, found := basicBySchemaID[sID]; found {
+			return reflect.New(bt.containerType).Interface().(Schemer), bt.schema, true
+		}
+	}
+	if r == nil {
+		return nil, nil, false
+	}
+	r.mu.RLock()
+	entry, found := r.entries[nID][sID]
+	r.mu.RUnlock()
+	if !found {
+		return nil, nil, false
+	}
+	return reflect.New(entry.typ).Interface().(Schemer), entry.schema, true
+}
+
+// Unmarshal decodes the data into a new allocated schemer of the type for its magic.
+// A nil registry only knows the basic types.
+func (r *Registry) Unmarshal(data []byte) (Schemer, error) {
+	if len(data) < MagicLen {
+		return nil, ErrNotAvroX
+	}
+	nID, sID, _, err := DecodeMagic(data[:MagicLen])
+	if err != nil {
+		return nil, err
+	}
+	value, schema, found := r.New(nID, sID)
+	if !found {
+		return nil, errors.Join(ErrSchemerNotFound, errors.New(NSV(nID, sID)))
+	}
+	if err = Unmarshal(data, value, schema); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
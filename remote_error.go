@@ -0,0 +1,162 @@
+package avrox
+
+import (
+	"errors"
+	"reflect"
+	"sync"
+)
+
+var _ error = (*RemoteError)(nil)
+
+var (
+	errorCodesMu sync.RWMutex
+	errorByCode  = map[string]error{}
+	codeByError  = map[error]string{}
+)
+
+// RegisterErrorCode registers a sentinel error with a (globally unique) code. The code
+// gets transported with the BasicError, so a decoded RemoteError still matches the
+// sentinel with errors.Is. The sentinels of this package use "avrox." and their name.
+// Registering the same code and error again does nothing. It panics if the code or the
+// error is already registered otherwise, or if the error is not comparable.
+func RegisterErrorCode(code string, err error) {
+	if err == nil || !reflect.TypeOf(err).Comparable() {
+		panic("error code needs a comparable error: " + code)
+	}
+	errorCodesMu.Lock()
+	defer errorCodesMu.Unlock()
+	if registered, found := errorByCode[code]; found {
+		if registered == err {
+			return
+		}
+		panic("error code registered twice: " + code)
+	}
+	if _, found := codeByError[err]; found {
+		panic("error registered with two codes: " + code)
+	}
+	errorByCode[code] = err
+	codeByError[err] = code
+}
+
+// sentinel creates an error of this package and registers it with the code "avrox." + name
+func sentinel(name, text string) error {
+	err := errors.New(text)
+	RegisterErrorCode("avrox."+name, err)
+	return err
+}
+
+// ErrorCode returns the registered code for a sentinel error (it does not unwrap err)
+func ErrorCode(err error) (string, bool) {
+	if err == nil || !reflect.TypeOf(err).Comparable() {
+		return "", false
+	}
+	errorCodesMu.RLock()
+	defer errorCodesMu.RUnlock()
+	code, found := codeByError[err]
+	return code, found
+}
+
+// RemoteError is an error which got transported with a BasicError. It matches the
+// registered sentinel of its code with errors.Is and unwraps to its causes.
+type RemoteError struct {
+	Code    string
+	Message string
+	Details map[string]string
+	NSV     string // The N.S.V of the message which failed (if any)
+	Causes  []error
+}
+
+// Error returns the message of the original error
+func (e *RemoteError) Error() string {
+	return e.Message
+}
+
+// Unwrap returns the causes (like an errors.Join error does)
+func (e *RemoteError) Unwrap() []error {
+	return e.Causes
+}
+
+// Is reports whether target is the registered sentinel for the code of the error
+func (e *RemoteError) Is(target error) bool {
+	if e.Code == "" {
+		return false
+	}
+	code, found := ErrorCode(target)
+	return found && code == e.Code
+}
+
+// NewBasicError creates the BasicError for err. The whole error tree (wrapped errors
+// and errors.Join) is stored. Registered sentinels keep their code, a RemoteError
+// keeps all its fields. It returns nil if err is nil.
+func NewBasicError(err error) *BasicError {
+	if err == nil {
+		return nil
+	}
+	root := errorCause(err)
+	return &BasicError{
+		Code:    root.Code,
+		Message: root.Message,
+		Details: root.Details,
+		NSV:     root.NSV,
+		Causes:  appendErrorCauses(nil, -1, err),
+	}
+}
+
+// appendErrorCauses appends the causes of err (depth first)
+func appendErrorCauses(causes []ErrorCause, parent int, err error) []ErrorCause {
+	var wrapped []error
+	switch u := err.(type) {
+	case interface{ Unwrap() []error }:
+		wrapped = u.Unwrap()
+	case interface{ Unwrap() error }:
+		wrapped = []error{u.Unwrap()}
+	}
+	for _, cause := range wrapped {
+		if cause == nil {
+			continue
+		}
+		ec := errorCause(cause)
+		ec.Parent = parent
+		causes = append(causes, ec)
+		causes = appendErrorCauses(causes, len(causes)-1, cause)
+	}
+	return causes
+}
+
+func errorCause(err error) ErrorCause {
+	if re, ok := err.(*RemoteError); ok {
+		return ErrorCause{Code: re.Code, Message: re.Message, Details: re.Details, NSV: re.NSV}
+	}
+	code, _ := ErrorCode(err)
+	return ErrorCause{Code: code, Message: err.Error()}
+}
+
+// RemoteError rebuilds the error tree
+func (b BasicError) RemoteError() *RemoteError {
+	root := &RemoteError{Code: b.Code, Message: b.Message, Details: nilIfEmpty(b.Details), NSV: b.NSV}
+	nodes := make([]*RemoteError, len(b.Causes))
+	for i, c := range b.Causes {
+		nodes[i] = &RemoteError{Code: c.Code, Message: c.Message, Details: nilIfEmpty(c.Details), NSV: c.NSV}
+		parent := root
+		if c.Parent >= 0 && c.Parent < i {
+			parent = nodes[c.Parent]
+		}
+		parent.Causes = append(parent.Causes, nodes[i])
+	}
+	return root
+}
+
+func nilIfEmpty(m map[string]string) map[string]string {
+	if len(m) == 0 {
+		return nil
+	}
+	return m
+}
+
+// MarshalError marshals err as BasicError (see NewBasicError). A nil error results in no data.
+func MarshalError(err error, cID CompressionID) ([]byte, error) {
+	if err == nil {
+		return nil, nil
+	}
+	return Marshal(NewBasicError(err), cID, basicSchema(BasicErrorSchemaID))
+}
@@ -89,6 +89,9 @@ func Unmarshal(data []byte, dst Schemer, schema avro.Schema) error {
 		return ErrWrongSchema
 	}
 
+	if sc, ok := dst.(selfCodec); ok {
+		return unmarshalSelf(data, sc)
+	}
 	return avro.Unmarshal(schema, data, dst)
 }
 
@@ -0,0 +1,166 @@
+package avrox
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+var (
+	ErrTimeOutOfRange = sentinel("ErrTimeOutOfRange", "time is outside the years 1678 to 2262 of nanosecond timestamps")
+	ErrZoneInvalid    = sentinel("ErrZoneInvalid", "zone has no IANA location name")
+)
+
+// TimeMicros is a timestamp which gets stored with microseconds (timestamp-micros).
+// Decoded values are in UTC.
+type TimeMicros struct {
+	time.Time
+}
+
+// TimeNanos is a timestamp which gets stored with nanoseconds (timestamp-nanos as long).
+// It covers the years 1678 to 2262. Decoded values are in UTC.
+type TimeNanos struct {
+	time.Time
+}
+
+// ZonedTime is a timestamp which keeps its location. The IANA name of the location
+// is stored next to the instant (with nanoseconds). When the location can not be
+// loaded while decoding, a fixed zone with the name and the stored offset is used.
+type ZonedTime struct {
+	time.Time
+}
+
+// LocalTimestamp is a wall clock time without a zone (local-timestamp-nanos as long).
+// The location of the time is ignored when encoding (it is not converted to UTC)
+// and decoded values have the wall clock in UTC.
+type LocalTimestamp struct {
+	time.Time
+}
+
+// AvroTimeMicros truncates a go time.Time to the value that gets stored as timestamp-micros
+// It also makes sure that the time is expressed in UTC()
+func AvroTimeMicros(t time.Time) time.Time {
+	return t.Truncate(time.Microsecond).UTC()
+}
+
+// AvroLocalTime returns the wall clock of t in UTC (like LocalTimestamp stores it)
+func AvroLocalTime(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+}
+
+// TimeMicros returns the stored value
+func (b BasicTimeMicros) TimeMicros() TimeMicros {
+	return TimeMicros{b.Value.UTC()}
+}
+
+// SetTimeMicros stores the value
+func (b *BasicTimeMicros) SetTimeMicros(t TimeMicros) {
+	b.Value = t.Time
+}
+
+// TimeNanos returns the stored value
+func (b BasicTimeNanos) TimeNanos() TimeNanos {
+	return TimeNanos{time.Unix(0, b.Value).UTC()}
+}
+
+// SetTimeNanos stores the value. It fails with ErrTimeOutOfRange outside the years 1678 to 2262.
+func (b *BasicTimeNanos) SetTimeNanos(t TimeNanos) error {
+	nanos, err := unixNano(t.Time)
+	if err != nil {
+		return err
+	}
+	b.Value = nanos
+	return nil
+}
+
+// ZonedTime returns the stored value. The stored offset wins over the location when
+// they do not agree (like when the tz database of the reader is different).
+func (b BasicZonedTime) ZonedTime() ZonedTime {
+	t := time.Unix(0, b.Value)
+	if loc, err := loadLocation(b.Location); err == nil {
+		if _, offset := t.In(loc).Zone(); offset == b.Offset {
+			return ZonedTime{t.In(loc)}
+		}
+	}
+	return ZonedTime{t.In(time.FixedZone(b.Location, b.Offset))}
+}
+
+// SetZonedTime stores the value. It fails with ErrTimeOutOfRange outside the years 1678
+// to 2262 and with ErrZoneInvalid for time.Local (whose name "Local" would be the zone
+// of the reader) and for zones whose name is the one of a different IANA location (like
+// a time.FixedZone("CET", 3600) in summer). Other unknown names (fixed zones) are
+// stored with their offset.
+func (b *BasicZonedTime) SetZonedTime(t ZonedTime) error {
+	nanos, err := unixNano(t.Time)
+	if err != nil {
+		return err
+	}
+	name := t.Location().String()
+	_, offset := t.Zone()
+	if name == "Local" {
+		return fmt.Errorf("%w: use the time in a loaded location instead of time.Local", ErrZoneInvalid)
+	}
+	if loc, errLoad := loadLocation(name); errLoad == nil {
+		if _, locOffset := t.In(loc).Zone(); locOffset != offset {
+			return fmt.Errorf("%w: %q has a different offset", ErrZoneInvalid, name)
+		}
+	}
+	b.Value = nanos
+	b.Location = name
+	b.Offset = offset
+	return nil
+}
+
+// LocalTimestamp returns the stored value
+func (b BasicLocalTimestamp) LocalTimestamp() LocalTimestamp {
+	return LocalTimestamp{time.Unix(0, b.Value).UTC()}
+}
+
+// SetLocalTimestamp stores the value. It fails with ErrTimeOutOfRange when the wall clock
+// is outside the years 1678 to 2262.
+func (b *BasicLocalTimestamp) SetLocalTimestamp(t LocalTimestamp) error {
+	nanos, err := unixNano(AvroLocalTime(t.Time))
+	if err != nil {
+		return err
+	}
+	b.Value = nanos
+	return nil
+}
+
+var (
+	minNanoTime = time.Unix(0, math.MinInt64)
+	maxNanoTime = time.Unix(0, math.MaxInt64)
+)
+
+// unixNano returns t.UnixNano or ErrTimeOutOfRange where it is undefined
+func unixNano(t time.Time) (int64, error) {
+	if t.Before(minNanoTime) || t.After(maxNanoTime) {
+		return 0, fmt.Errorf("%w: %s", ErrTimeOutOfRange, t)
+	}
+	return t.UnixNano(), nil
+}
+
+// locations caches the results of time.LoadLocation (*time.Location or error)
+var locations sync.Map
+
+// loadLocation is time.LoadLocation with a cache. It does not load "" and "Local"
+// (which are UTC and the zone of this process).
+func loadLocation(name string) (*time.Location, error) {
+	if name == "" || name == "Local" {
+		return nil, ErrZoneInvalid
+	}
+	if cached, found := locations.Load(name); found {
+		if loc, ok := cached.(*time.Location); ok {
+			return loc, nil
+		}
+		return nil, cached.(error)
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		locations.Store(name, err)
+		return nil, err
+	}
+	locations.Store(name, loc)
+	return loc, nil
+}
@@ -0,0 +1,80 @@
+package avrox
+
+import (
+	"crypto/rand"
+	"encoding"
+	"encoding/hex"
+	"errors"
+	"fmt"
+)
+
+var ErrUUIDInvalid = sentinel("ErrUUIDInvalid", "uuid is invalid")
+
+var (
+	_ fmt.Stringer             = UUID{}
+	_ encoding.TextMarshaler   = UUID{}
+	_ encoding.TextUnmarshaler = (*UUID)(nil)
+)
+
+// UUID is an RFC 4122 UUID. It is stored with the avro uuid logical type (as string)
+// through its TextMarshaler implementation.
+type UUID [16]byte
+
+// NewUUID creates a random (version 4) UUID
+func NewUUID() (UUID, error) {
+	var u UUID
+	if _, err := rand.Read(u[:]); err != nil {
+		return UUID{}, err
+	}
+	u[6] = (u[6] & 0x0f) | 0x40 // version 4
+	u[8] = (u[8] & 0x3f) | 0x80 // variant RFC 4122
+	return u, nil
+}
+
+// ParseUUID parses the canonical form (xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx)
+func ParseUUID(s string) (UUID, error) {
+	var u UUID
+	if len(s) != 36 || s[8] != '-' || s[13] != '-' || s[18] != '-' || s[23] != '-' {
+		return UUID{}, ErrUUIDInvalid
+	}
+	h := s[0:8] + s[9:13] + s[14:18] + s[19:23] + s[24:36]
+	if _, err := hex.Decode(u[:], []byte(h)); err != nil {
+		return UUID{}, errors.Join(ErrUUIDInvalid, err)
+	}
+	return u, nil
+}
+
+// MustParseUUID parses the UUID and panics on errors
+func MustParseUUID(s string) UUID {
+	u, err := ParseUUID(s)
+	if err != nil {
+		panic(err)
+	}
+	return u
+}
+
+// IsZero reports whether the UUID is the nil UUID
+func (u UUID) IsZero() bool {
+	return u == UUID{}
+}
+
+// String returns the canonical form of the UUID
+func (u UUID) String() string {
+	h := hex.EncodeToString(u[:])
+	return h[0:8] + "-" + h[8:12] + "-" + h[12:16] + "-" + h[16:20] + "-" + h[20:32]
+}
+
+// MarshalText implements the encoding.TextMarshaler
+func (u UUID) MarshalText() ([]byte, error) {
+	return []byte(u.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler
+func (u *UUID) UnmarshalText(data []byte) error {
+	parsed, err := ParseUUID(string(data))
+	if err != nil {
+		return err
+	}
+	*u = parsed
+	return nil
+}